@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"quiz-service/init/config"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/events"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/repository/postgres"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/certificate"
+	"quiz-service/pkg/constants"
+	"quiz-service/pkg/hash"
+)
+
+// withService wires the repositories and services the same way the server
+// does, so the admin commands go through the same rules as the API. Their logs
+// go to the files only, stdout is left for results.
+func withService(ctx context.Context, cfg *config.Config, run func(serv *service.Service) error) error {
+	postgresLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.Postgres, nil)
+	if err != nil {
+		return err
+	}
+	quizLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.Quiz, nil)
+	if err != nil {
+		return err
+	}
+
+	// unlike serve, the admin commands never migrate on their own
+	db, err := postgres.Connect(ctx, cfg, postgresLogger)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewRepository(db, postgresLogger)
+	serv := service.NewService(repo, hash.NewSHA512Hasher(cfg.PasswordSalt), certificate.NewPDFGenerator(), events.NewBroker(), quizLogger)
+
+	if err := serv.HealthService.Ready(ctx); err != nil {
+		if errors.Is(err, constants.ErrorSchemaVersion) {
+			return fmt.Errorf("%w, see migrate status", err)
+		}
+		return err
+	}
+
+	return run(serv)
+}
+
+// tenant resolves the -organization flag of a command.
+func tenant(ctx context.Context, serv *service.Service, name string) (*entities.Organization, error) {
+	if name == "" {
+		name = entities.DefaultOrganization
+	}
+	organization, err := serv.OrganizationsService.OrganizationGet(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("organization %q: %w", name, err)
+	}
+	return organization, nil
+}
+
+// password takes the -password flag, or the first line of stdin when the flag
+// is empty, so the password need not end up in the shell history.
+func password(value string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		if err != nil {
+			return "", fmt.Errorf("reading the password from stdin: %w", err)
+		}
+		return "", errors.New("the password is empty")
+	}
+
+	return line, nil
+}
@@ -3,62 +3,125 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"golang.org/x/sync/errgroup"
-	"net/http"
+	"os"
 	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
 
 	"quiz-service/init/config"
-	"quiz-service/init/logger"
-	"quiz-service/internal/server"
-	"syscall"
 )
 
+// errUsage marks a command line that could not be understood; the FlagSet has
+// already printed what was wrong by the time it is returned.
+var errUsage = errors.New("usage")
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, cfg *config.Config, args []string) error
+}
+
+var commands = []command{
+	{name: "serve", summary: "run the HTTP and gRPC servers, migrating the schema first unless db.migrate_on_start is off", run: serve},
+	{name: "migrate", summary: "up | down | status | force: manage the database schema", run: migrateCommand},
+	{name: "organization", summary: "create | show: manage organizations and their invite codes", run: organizationCommand},
+	{name: "user", summary: "create | reset-password | set-role | disable: manage accounts", run: userCommand},
+	{name: "variant", summary: "import | export | list: manage variants as JSON files", run: variantCommand},
+	{name: "seed", summary: "load the demo organization, users and variant", run: seed},
+}
+
 func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-	cfg := &config.ServerConfig
+	var configFiles config.Files
+	flag.Var(&configFiles, "config", "config file, repeat to layer several; later files override earlier ones (default "+config.DefaultFile+")")
+	flag.Usage = usage
+	flag.Parse()
 
-	if err := config.InitConfig(); err != nil {
-		fmt.Println(err.Error())
-		cancel()
+	// without a subcommand the binary keeps behaving like the plain server it
+	// used to be
+	name, args := "serve", flag.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
 
-	httpLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.HttpLoggerPath)
-	if err != nil {
-		cancel()
-	}
-	postgresLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.PostgresLoggerPath)
-	if err != nil {
-		cancel()
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
 	}
-	quizLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.QuizLoggerPath)
-	if err != nil {
-		cancel()
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
 	}
 
-	app, err := server.NewHTTPServer(ctx, cfg, httpLogger, postgresLogger, quizLogger)
-	if err != nil {
-		cancel()
+	// without a valid config nothing below can start, so there is nothing to
+	// shut down either
+	if err := config.InitConfig(configFiles...); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(2)
 	}
-	quizLogger.Info("server configured")
 
-	if app != nil {
-		errs, gCtx := errgroup.WithContext(ctx)
-		errs.Go(func() error {
-			return app.Run()
-		})
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	err := cmd.run(ctx, &config.ServerConfig, args)
+	cancel()
+
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
 
-		errs.Go(func() error {
-			<-gCtx.Done()
-			return app.Shutdown(gCtx)
-		})
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [--config file]... <command> [arguments]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nRun '%s <command> -h' for the arguments of a command.\n\nFlags:\n", os.Args[0])
+	flag.PrintDefaults()
+}
 
-		if err := errs.Wait(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			cancel()
+// subcommand picks the action of a command with several, like "migrate up",
+// from the first argument.
+func subcommand(name string, args []string, actions map[string]func([]string) error) error {
+	if len(args) > 0 {
+		if action, ok := actions[args[0]]; ok {
+			return action(args[1:])
 		}
+		fmt.Fprintf(os.Stderr, "unknown %s action %q\n", name, args[0])
 	}
 
-	<-ctx.Done()
+	names := make([]string, 0, len(actions))
+	for action := range actions {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(os.Stderr, "Usage: %s %s [arguments]\n", name, strings.Join(names, " | "))
 
-	quizLogger.Info("quiz shutdown")
+	return errUsage
+}
+
+// parse parses the flags of an action and rejects stray positional arguments.
+func parse(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return errUsage
+	}
+	return nil
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/repository/postgres"
+)
+
+func migrateCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	return subcommand("migrate", args, map[string]func([]string) error{
+		"up": func(args []string) error {
+			if err := parse(flag.NewFlagSet("migrate up", flag.ContinueOnError), args); err != nil {
+				return err
+			}
+			return withMigrations(ctx, cfg, func(m *postgres.Migrations) error {
+				return done(m, m.Up())
+			})
+		},
+		"down": func(args []string) error {
+			fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+			steps := fs.Int("steps", 1, "number of migrations to roll back")
+			all := fs.Bool("all", false, "roll back every migration, dropping all data")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if *steps < 1 && !*all {
+				fmt.Fprintln(fs.Output(), "-steps must be at least 1")
+				return errUsage
+			}
+			if *all {
+				*steps = 0
+			}
+			return withMigrations(ctx, cfg, func(m *postgres.Migrations) error {
+				return done(m, m.Down(*steps))
+			})
+		},
+		"status": func(args []string) error {
+			if err := parse(flag.NewFlagSet("migrate status", flag.ContinueOnError), args); err != nil {
+				return err
+			}
+			return withMigrations(ctx, cfg, status)
+		},
+		"force": func(args []string) error {
+			fs := flag.NewFlagSet("migrate force", flag.ContinueOnError)
+			fs.Usage = func() {
+				fmt.Fprintln(fs.Output(), "Usage: migrate force <version>\n\nMarks <version> as applied and clean without running it.")
+			}
+			if len(args) != 1 {
+				fs.Usage()
+				return errUsage
+			}
+			version, err := strconv.Atoi(args[0])
+			if err != nil || version < 0 {
+				fs.Usage()
+				return errUsage
+			}
+			return withMigrations(ctx, cfg, func(m *postgres.Migrations) error {
+				return done(m, m.Force(version))
+			})
+		},
+	})
+}
+
+// withMigrations runs a change under the migrations lock, so it never
+// overlaps with a server migrating on start.
+func withMigrations(ctx context.Context, cfg *config.Config, run func(m *postgres.Migrations) error) error {
+	m, err := postgres.NewMigrations(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	return errors.Join(run(m), m.Close())
+}
+
+// done reports where the schema ended up after a change.
+func done(m *postgres.Migrations, err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+	} else if err != nil {
+		return err
+	}
+	return status(m)
+}
+
+func status(m *postgres.Migrations) error {
+	schema, latest, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version %d of %d", schema.Version, latest)
+	switch {
+	case schema.Dirty:
+		fmt.Print(", dirty: fix the schema by hand and run migrate force")
+	case schema.Version > latest:
+		fmt.Print(", newer than this build: the server will not start with it")
+	case schema.Version < latest:
+		fmt.Printf(", %d pending", latest-schema.Version)
+	}
+	fmt.Println()
+
+	return nil
+}
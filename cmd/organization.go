@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+)
+
+func organizationCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	return subcommand("organization", args, map[string]func([]string) error{
+		"create": func(args []string) error {
+			fs := flag.NewFlagSet("organization create", flag.ContinueOnError)
+			name := fs.String("name", "", "name of the organization (required)")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "name", *name); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				organization, err := serv.OrganizationsService.OrganizationAdd(ctx, &entities.Organization{Name: *name})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("created %s (id %d), invite code %s\n", organization.Name, organization.Id, organization.InviteCode)
+				return nil
+			})
+		},
+		"show": func(args []string) error {
+			fs := flag.NewFlagSet("organization show", flag.ContinueOnError)
+			name := fs.String("name", entities.DefaultOrganization, "name of the organization")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				organization, err := tenant(ctx, serv, *name)
+				if err != nil {
+					return err
+				}
+				invite := organization.InviteCode
+				if invite == "" {
+					invite = "none, users join without a code"
+				}
+				fmt.Printf("%s (id %d), invite code %s\n", organization.Name, organization.Id, invite)
+				return nil
+			})
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/constants"
+)
+
+//go:embed demo/variant.json
+var demoVariant []byte
+
+// demoUsers are created by seed with the password given to it.
+var demoUsers = []struct {
+	login string
+	role  string
+}{
+	{login: "demo-admin", role: entities.RoleAdmin},
+	{login: "demo-student", role: entities.RoleUser},
+}
+
+// seed loads demo data for trying the service out. Whatever already exists is
+// left as it is, so running it twice is harmless.
+func seed(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	organization := fs.String("organization", "demo", "organization to create and fill")
+	pass := fs.String("password", "demo", "password of the demo users; only for local use")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var variant = new(entities.Variant)
+	if err := json.NewDecoder(bytes.NewReader(demoVariant)).Decode(variant); err != nil {
+		return err
+	}
+
+	return withService(ctx, cfg, func(serv *service.Service) error {
+		_, err := serv.OrganizationsService.OrganizationAdd(ctx, &entities.Organization{Name: *organization})
+		if err := skipExisting(err, "organization "+*organization); err != nil {
+			return err
+		}
+		org, err := tenant(ctx, serv, *organization)
+		if err != nil {
+			return err
+		}
+		if org.InviteCode != "" {
+			fmt.Printf("organization %s invite code %s\n", org.Name, org.InviteCode)
+		}
+
+		for _, demo := range demoUsers {
+			_, err := serv.RegisterService.Register(ctx, &entities.Register{Login: demo.login, Password: *pass, Organization: org.Name})
+			if errors.Is(err, constants.ErrorUserAlreadyExists) {
+				// the login may be taken in another organization, so an existing
+				// user is left untouched rather than promoted
+				fmt.Printf("user %s already exists, skipped\n", demo.login)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("user %s: %w", demo.login, err)
+			}
+			if err := serv.UserService.UserRoleSet(ctx, demo.login, demo.role); err != nil {
+				return fmt.Errorf("user %s: %w", demo.login, err)
+			}
+			fmt.Printf("user %s created (%s)\n", demo.login, demo.role)
+		}
+
+		err = importVariant(ctx, serv, org.Id, variant)
+		return skipExisting(err, "variant "+variant.Name)
+	})
+}
+
+// skipExisting reports what seed did with one object, treating "already
+// exists" as done.
+func skipExisting(err error, what string) error {
+	switch {
+	case errors.Is(err, constants.ErrorOrganizationAlreadyExists), errors.Is(err, constants.ErrorVariantAlreadyExists):
+		fmt.Printf("%s already exists, skipped\n", what)
+		return nil
+	case err != nil:
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	fmt.Printf("%s created\n", what)
+	return nil
+}
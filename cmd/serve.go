@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"golang.org/x/sync/errgroup"
+	"net/http"
+	"os"
+
+	"quiz-service/init/config"
+	"quiz-service/init/logger"
+	"quiz-service/internal/server"
+	"quiz-service/internal/tracing"
+	"time"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// serve returns what made the servers stop, so a port already in use or a
+// broken tracing exporter ends the process with a non-zero status. A signal
+// is a normal shutdown and returns nil.
+func serve(ctx context.Context, cfg *config.Config, args []string) error {
+	if err := parse(flag.NewFlagSet("serve", flag.ContinueOnError), args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	httpLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.Http, os.Stdout)
+	if err != nil {
+		return err
+	}
+	postgresLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.Postgres, os.Stdout)
+	if err != nil {
+		return err
+	}
+	quizLogger, err := logger.NewLogger(ctx, cfg.Debug, cfg.Log.Quiz, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	shutdownTracing, err := tracing.Init(ctx, cfg)
+	if err != nil {
+		quizLogger.Error(err.Error())
+		return err
+	}
+	defer func() {
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelFlush()
+		if err := shutdownTracing(flushCtx); err != nil {
+			quizLogger.Error(err.Error())
+		}
+	}()
+
+	app, err := server.NewHTTPServer(ctx, cfg, httpLogger, postgresLogger, quizLogger)
+	if err != nil {
+		quizLogger.Error(err.Error())
+		return err
+	}
+	quizLogger.Info("server configured")
+
+	grpcApp := server.NewGRPCServer(cfg, app.Service(), httpLogger)
+
+	errs, gCtx := errgroup.WithContext(ctx)
+	errs.Go(func() error {
+		return app.Run()
+	})
+
+	errs.Go(func() error {
+		return grpcApp.Run()
+	})
+
+	errs.Go(func() error {
+		return app.RunJobs(gCtx)
+	})
+
+	errs.Go(func() error {
+		<-gCtx.Done()
+
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelShutdown()
+
+		return errors.Join(app.Shutdown(shutdownCtx), grpcApp.Shutdown(shutdownCtx))
+	})
+
+	// http.ErrServerClosed is how the HTTP server reports a normal Shutdown
+	err = errs.Wait()
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	if err != nil {
+		quizLogger.Error(err.Error())
+	}
+
+	quizLogger.Info("quiz shutdown")
+
+	return err
+}
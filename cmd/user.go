@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/constants"
+)
+
+func userCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	return subcommand("user", args, map[string]func([]string) error{
+		"create": func(args []string) error {
+			fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+			organization := fs.String("organization", entities.DefaultOrganization, "organization the user belongs to")
+			login := fs.String("login", "", "login, unique across organizations (required)")
+			pass := fs.String("password", "", "password; read from the first line of stdin when empty")
+			role := fs.String("role", entities.RoleUser, "role: "+entities.RoleUser+" or "+entities.RoleAdmin)
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "login", *login); err != nil {
+				return err
+			}
+			// checked up front, the user must not be created with the wrong role
+			if *role != entities.RoleUser && *role != entities.RoleAdmin {
+				fmt.Fprintln(fs.Output(), constants.ErrorUserRoleInvalid.Error())
+				return errUsage
+			}
+			secret, err := password(*pass)
+			if err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				user, err := serv.RegisterService.Register(ctx, &entities.Register{
+					Login:        *login,
+					Password:     secret,
+					Organization: *organization,
+				})
+				if err != nil {
+					return err
+				}
+				if *role != entities.RoleUser {
+					if err := serv.UserService.UserRoleSet(ctx, user.Login, *role); err != nil {
+						return fmt.Errorf("user %s was created, but setting the role failed: %w", user.Login, err)
+					}
+				}
+
+				fmt.Printf("created %s (id %d, role %s) in %s\n", user.Login, user.ID, *role, *organization)
+				return nil
+			})
+		},
+		"reset-password": func(args []string) error {
+			fs := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+			login := fs.String("login", "", "login of the user (required)")
+			pass := fs.String("password", "", "new password; read from the first line of stdin when empty")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "login", *login); err != nil {
+				return err
+			}
+			secret, err := password(*pass)
+			if err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				if err := serv.UserService.UserPasswordReset(ctx, *login, secret); err != nil {
+					return err
+				}
+				fmt.Printf("password of %s reset, the user has to log in again\n", *login)
+				return nil
+			})
+		},
+		"set-role": func(args []string) error {
+			fs := flag.NewFlagSet("user set-role", flag.ContinueOnError)
+			login := fs.String("login", "", "login of the user (required)")
+			role := fs.String("role", "", "role: "+entities.RoleUser+" or "+entities.RoleAdmin+" (required)")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "login", *login); err != nil {
+				return err
+			}
+			if err := required(fs, "role", *role); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				if err := serv.UserService.UserRoleSet(ctx, *login, *role); err != nil {
+					return err
+				}
+				fmt.Printf("%s is now %s\n", *login, *role)
+				return nil
+			})
+		},
+		"disable": func(args []string) error {
+			fs := flag.NewFlagSet("user disable", flag.ContinueOnError)
+			login := fs.String("login", "", "login of the user (required)")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "login", *login); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				if err := serv.UserService.UserDisable(ctx, *login); err != nil {
+					return err
+				}
+				fmt.Printf("%s disabled and logged out\n", *login)
+				return nil
+			})
+		},
+	})
+}
+
+func required(fs *flag.FlagSet, name, value string) error {
+	if value != "" {
+		return nil
+	}
+	fmt.Fprintf(fs.Output(), "-%s is required\n", name)
+	fs.Usage()
+	return errUsage
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/constants"
+)
+
+func variantCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	return subcommand("variant", args, map[string]func([]string) error{
+		"import": func(args []string) error {
+			fs := flag.NewFlagSet("variant import", flag.ContinueOnError)
+			organization := fs.String("organization", entities.DefaultOrganization, "organization to import into")
+			file := fs.String("file", "-", "JSON file written by variant export, - for stdin")
+			name := fs.String("name", "", "import under this name instead of the one in the file")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+
+			variant, err := readVariant(*file)
+			if err != nil {
+				return err
+			}
+			if *name != "" {
+				variant.Name = *name
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				org, err := tenant(ctx, serv, *organization)
+				if err != nil {
+					return err
+				}
+				if err := importVariant(ctx, serv, org.Id, variant); err != nil {
+					return err
+				}
+				fmt.Printf("imported %s with %d questions into %s\n", variant.Name, len(variant.Questions), org.Name)
+				return nil
+			})
+		},
+		"export": func(args []string) error {
+			fs := flag.NewFlagSet("variant export", flag.ContinueOnError)
+			organization := fs.String("organization", entities.DefaultOrganization, "organization the variant belongs to")
+			name := fs.String("name", "", "name of the variant (required)")
+			file := fs.String("file", "-", "file to write the JSON to, - for stdout")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+			if err := required(fs, "name", *name); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				org, err := tenant(ctx, serv, *organization)
+				if err != nil {
+					return err
+				}
+				variant, err := exportVariant(ctx, serv, org.Id, *name)
+				if err != nil {
+					return err
+				}
+				return writeVariant(*file, variant)
+			})
+		},
+		"list": func(args []string) error {
+			fs := flag.NewFlagSet("variant list", flag.ContinueOnError)
+			organization := fs.String("organization", entities.DefaultOrganization, "organization to list")
+			if err := parse(fs, args); err != nil {
+				return err
+			}
+
+			return withService(ctx, cfg, func(serv *service.Service) error {
+				org, err := tenant(ctx, serv, *organization)
+				if err != nil {
+					return err
+				}
+				variants, err := serv.VariantService.VariantList(ctx, org.Id)
+				if errors.Is(err, constants.ErrorNoVariantsYet) {
+					fmt.Println(err.Error())
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "NAME\tSTATUS\tQUESTIONS\tPASS MARK\tRESTRICTED\tPRACTICE\tOPENS\tCLOSES")
+				for _, variant := range variants {
+					fmt.Fprintf(w, "%s\t%s\t%d\t%g\t%t\t%t\t%s\t%s\n", variant.Name, variant.Status, len(variant.Questions),
+						variant.PassMark, variant.Restricted, variant.Practice, timeOrDash(variant.OpensAt), timeOrDash(variant.ClosesAt))
+				}
+				return w.Flush()
+			})
+		},
+	})
+}
+
+// exportVariant reads the variant together with what VariantGet leaves out
+// for players: the feedback and correctness of answers, explanations and hints.
+func exportVariant(ctx context.Context, serv *service.Service, tenantId int, name string) (*entities.Variant, error) {
+	variant, err := serv.VariantService.VariantGet(ctx, tenantId, name)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]*entities.Question, 0, len(variant.Questions))
+	for _, question := range variant.Questions {
+		full, err := serv.QuestionsService.QuestionGet(ctx, tenantId, variant.Id, question.Id)
+		if err != nil {
+			return nil, fmt.Errorf("question %q: %w", question.Question, err)
+		}
+		questions = append(questions, full)
+	}
+	variant.Questions = questions
+	variant.Status = ""
+
+	return variant, nil
+}
+
+// importVariant adds the variant and then its questions one by one, through
+// the same checks as the API. A question that is refused takes the whole
+// variant back out, so a failed import can simply be run again.
+func importVariant(ctx context.Context, serv *service.Service, tenantId int, variant *entities.Variant) error {
+	if err := binding.Validator.ValidateStruct(variant); err != nil {
+		return err
+	}
+	for _, question := range variant.Questions {
+		if err := binding.Validator.ValidateStruct(question); err != nil {
+			return fmt.Errorf("question %q: %w", question.Question, err)
+		}
+	}
+
+	if err := serv.VariantService.VariantAdd(ctx, tenantId, 0, variant); err != nil {
+		return err
+	}
+	created, err := serv.VariantService.VariantGet(ctx, tenantId, variant.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, question := range variant.Questions {
+		if err := serv.QuestionsService.QuestionAdd(ctx, tenantId, created.Id, question); err != nil {
+			err = fmt.Errorf("question %q: %w", question.Question, err)
+			return errors.Join(err, serv.VariantService.VariantRemove(ctx, tenantId, variant.Name))
+		}
+	}
+
+	return nil
+}
+
+func readVariant(file string) (*entities.Variant, error) {
+	var in io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var variant = new(entities.Variant)
+	if err := json.NewDecoder(in).Decode(variant); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	return variant, nil
+}
+
+func writeVariant(file string, variant *entities.Variant) error {
+	var out io.Writer = os.Stdout
+	if file != "-" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(variant)
+}
+
+func timeOrDash(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
@@ -1,44 +1,162 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var ServerConfig Config
 
 type Config struct {
-	Debug        bool     `mapstructure:"debug"`
-	Port         int      `mapstructure:"port"`
-	Entry        string   `mapstructure:"entry"`
-	PasswordSalt string   `mapstructure:"password_salt"`
-	Postgres     postgres `mapstructure:"db"`
-	Log          log      `mapstructure:"log"`
+	Debug         bool          `mapstructure:"debug"`
+	Port          int           `mapstructure:"port"`
+	GrpcPort      int           `mapstructure:"grpc_port"`
+	MetricsPort   int           `mapstructure:"metrics_port"`
+	ShutdownDrain time.Duration `mapstructure:"shutdown_drain"`
+	Entry         string        `mapstructure:"entry"`
+	PasswordSalt  string        `mapstructure:"password_salt"`
+	Postgres      postgres      `mapstructure:"db"`
+	Log           log           `mapstructure:"log"`
+	Tracing       tracing       `mapstructure:"tracing"`
 }
 
 type postgres struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Username string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port"`
+	Username       string        `mapstructure:"user"`
+	Password       string        `mapstructure:"password"`
+	Database       string        `mapstructure:"database"`
+	SSLMode        string        `mapstructure:"sslmode"`
+	SSLRootCert    string        `mapstructure:"sslrootcert"`
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	ConnectMaxWait   time.Duration `mapstructure:"connect_max_wait"`
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"`
+	OperationTimeout time.Duration `mapstructure:"operation_timeout"`
+
+	MigrateOnStart bool `mapstructure:"migrate_on_start"`
+}
+
+// DSN is the connection url for both pgx and the migrations' lib/pq, so only
+// parameters the two understand go into it.
+func (p postgres) DSN() string {
+	query := url.Values{}
+	query.Set("sslmode", p.SSLMode)
+	if p.SSLRootCert != "" {
+		query.Set("sslrootcert", p.SSLRootCert)
+	}
+	if p.ConnectTimeout > 0 {
+		query.Set("connect_timeout", strconv.Itoa(int(p.ConnectTimeout.Seconds())))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgresql",
+		User:     url.UserPassword(p.Username, p.Password),
+		Host:     net.JoinHostPort(p.Host, strconv.Itoa(p.Port)),
+		Path:     "/" + p.Database,
+		RawQuery: query.Encode(),
+	}
+	return dsn.String()
 }
 
 type log struct {
-	HttpLoggerPath     string `mapstructure:"http_logger_path"`
-	PostgresLoggerPath string `mapstructure:"postgres_logger_path"`
-	QuizLoggerPath     string `mapstructure:"quiz_logger_path"`
+	Http     LogFile `mapstructure:"http"`
+	Postgres LogFile `mapstructure:"postgres"`
+	Quiz     LogFile `mapstructure:"quiz"`
+}
+
+// LogFile is where one logger writes and how long its files are kept. The
+// current file is rotated when it reaches MaxSizeMB and at midnight; rotated
+// files are removed once there are more than MaxBackups or they are older
+// than MaxAgeDays, zero meaning no limit.
+type LogFile struct {
+	Dir        string `mapstructure:"dir"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"`
+	MaxBackups int    `mapstructure:"max_backups"`
+	MaxAgeDays int    `mapstructure:"max_age_days"`
+	Compress   bool   `mapstructure:"compress"`
 }
 
-func InitConfig() error {
-	viper.SetConfigName("config")
-	viper.SetConfigType("json")
-	viper.AddConfigPath("./configs")
+type tracing struct {
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
 
-	if err := viper.ReadInConfig(); err != nil {
+// DefaultFile is read when no file is given on the command line.
+const DefaultFile = "./configs/config.json"
+
+// Files collects repeated --config flags.
+type Files []string
+
+func (f *Files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *Files) Set(file string) error {
+	*f = append(*f, file)
+	return nil
+}
+
+// InitConfig reads files in order, each overriding the keys it sets, then
+// applies the environment (see bindEnv) and validates the result.
+func InitConfig(files ...string) error {
+	if len(files) == 0 {
+		files = []string{DefaultFile}
+	}
+
+	setDefaults()
+
+	for i, file := range files {
+		viper.SetConfigFile(file)
+
+		read := viper.MergeInConfig
+		if i == 0 {
+			read = viper.ReadInConfig
+		}
+		if err := read(); err != nil {
+			return fmt.Errorf("config %s: %w", file, err)
+		}
+	}
+
+	if err := bindEnv(); err != nil {
 		return err
 	}
 
-	if err := viper.Unmarshal(&ServerConfig); err != nil {
+	var cfg Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return err
+	}
+	if err := cfg.validate(); err != nil {
 		return err
 	}
 
+	ServerConfig = cfg
 	return nil
 }
+
+func setDefaults() {
+	viper.SetDefault("db.port", 5432)
+	viper.SetDefault("db.sslmode", "require")
+	viper.SetDefault("db.connect_timeout", 5*time.Second)
+	viper.SetDefault("db.max_open_conns", 25)
+	viper.SetDefault("db.max_idle_conns", 25)
+	viper.SetDefault("db.conn_max_lifetime", 30*time.Minute)
+	viper.SetDefault("db.conn_max_idle_time", 5*time.Minute)
+	viper.SetDefault("db.connect_max_wait", time.Minute)
+	viper.SetDefault("db.statement_timeout", 5*time.Second)
+	viper.SetDefault("db.operation_timeout", 10*time.Second)
+	viper.SetDefault("db.migrate_on_start", true)
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+}
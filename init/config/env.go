@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// EnvPrefix starts the variable of every key: db.password is QUIZ_DB_PASSWORD,
+// log.http.dir is QUIZ_LOG_HTTP_DIR.
+const EnvPrefix = "QUIZ"
+
+// bindEnv lets a variable override each key of Config, whether or not a file
+// sets it. A variable with the _FILE suffix, e.g. QUIZ_DB_PASSWORD_FILE, names
+// a file holding the value instead, for docker and kubernetes secrets; it
+// wins over the plain variable.
+func bindEnv() error {
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	for _, key := range keys(reflect.TypeOf(Config{}), "") {
+		if err := viper.BindEnv(key); err != nil {
+			return err
+		}
+
+		variable := envName(key) + "_FILE"
+		path, ok := os.LookupEnv(variable)
+		if !ok {
+			continue
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", variable, err)
+		}
+		viper.Set(key, strings.TrimRight(string(value), "\r\n"))
+	}
+
+	return nil
+}
+
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// keys lists the dotted mapstructure keys of the leaf fields of t.
+func keys(t reflect.Type, prefix string) []string {
+	var result []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+
+		if field.Type.Kind() == reflect.Struct {
+			result = append(result, keys(field.Type, key+".")...)
+			continue
+		}
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sslModes are the modes both pgx and lib/pq, used by the migrations, accept.
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+var exporters = []string{"", "stdout", "otlp"}
+
+// validate reports every problem at once, naming keys the way they are
+// written in the file and in the environment.
+func (c *Config) validate() error {
+	var problems []string
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s (%s): %s", key, envName(key), fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validPort(c.Port), "port", "must be a port number, got %d", c.Port)
+	check(validPort(c.GrpcPort), "grpc_port", "must be a port number, got %d", c.GrpcPort)
+	check(c.MetricsPort == 0 || validPort(c.MetricsPort), "metrics_port", "must be 0 or a port number, got %d", c.MetricsPort)
+	check(c.Port != c.GrpcPort && c.Port != c.MetricsPort, "port", "must differ from grpc_port and metrics_port")
+	check(c.ShutdownDrain >= 0, "shutdown_drain", "must not be negative")
+	check(c.Entry == "" || strings.HasPrefix(c.Entry, "/") && !strings.HasSuffix(c.Entry, "/"), "entry",
+		`must be empty or start and not end with "/", got %q`, c.Entry)
+	check(c.PasswordSalt != "", "password_salt", "is required")
+
+	check(c.Postgres.Host != "", "db.host", "is required")
+	check(validPort(c.Postgres.Port), "db.port", "must be a port number, got %d", c.Postgres.Port)
+	check(c.Postgres.Username != "", "db.user", "is required")
+	check(c.Postgres.Database != "", "db.database", "is required")
+	check(oneOf(c.Postgres.SSLMode, sslModes), "db.sslmode", "must be one of %s, got %q", strings.Join(sslModes, ", "), c.Postgres.SSLMode)
+	check(c.Postgres.ConnectTimeout >= 0, "db.connect_timeout", "must not be negative")
+	check(c.Postgres.MaxOpenConns >= 0, "db.max_open_conns", "must not be negative")
+	check(c.Postgres.MaxIdleConns >= 0, "db.max_idle_conns", "must not be negative")
+	check(c.Postgres.ConnectMaxWait >= 0, "db.connect_max_wait", "must not be negative")
+	check(c.Postgres.StatementTimeout >= 0, "db.statement_timeout", "must not be negative")
+	check(c.Postgres.OperationTimeout >= 0, "db.operation_timeout", "must not be negative")
+	check(c.Postgres.MaxOpenConns == 0 || c.Postgres.MaxIdleConns <= c.Postgres.MaxOpenConns, "db.max_idle_conns",
+		"must not exceed db.max_open_conns")
+
+	for _, file := range []struct {
+		key string
+		LogFile
+	}{{"log.http", c.Log.Http}, {"log.postgres", c.Log.Postgres}, {"log.quiz", c.Log.Quiz}} {
+		check(file.Dir != "", file.key+".dir", "is required")
+		check(file.MaxSizeMB >= 0 && file.MaxBackups >= 0 && file.MaxAgeDays >= 0, file.key, "limits must not be negative")
+	}
+
+	check(oneOf(c.Tracing.Exporter, exporters), "tracing.exporter", `must be "", "stdout" or "otlp", got %q`, c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "tracing.endpoint", "is required for the otlp exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("invalid config:\n  " + strings.Join(problems, "\n  "))
+}
+
+func validPort(port int) bool {
+	return port > 0 && port < 1<<16
+}
+
+func oneOf(value string, allowed []string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
@@ -22,14 +22,15 @@ func HTTPLogger(param gin.LogFormatterParams) string {
 		color = Green
 	}
 
-	return fmt.Sprintf("[HTTP] [%s] \033[%sm %d \033[0m %s | %s | %s | %s | %s\n",
+	return fmt.Sprintf("[HTTP] [%s] \033[%sm %d \033[0m %s | %s | %s | %s | %s | %s\n",
 		param.TimeStamp.Format("2006-01-02 15:04:05"),
 		color,
 		param.StatusCode,
 		param.Method,
-		param.Path,
+		redactPath(param.Path),
 		param.Latency.String(),
 		param.ClientIP,
 		param.Request.UserAgent(),
+		param.Keys["request_id"],
 	)
 }
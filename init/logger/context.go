@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field names shared by every logger, so one request can be followed across
+// the http, quiz and postgres logs.
+const (
+	RequestIDField = "request_id"
+	UserIDField    = "user_id"
+	RouteField     = "route"
+	TraceIDField   = "trace_id"
+)
+
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying fields in addition to the
+// ones already there. Loggers add them to every line once given the context
+// with WithContext.
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	previous := fieldsFrom(ctx)
+
+	merged := make(logrus.Fields, len(previous)+len(fields))
+	for key, value := range previous {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+func fieldsFrom(ctx context.Context) logrus.Fields {
+	fields, _ := ctx.Value(fieldsKey{}).(logrus.Fields)
+	return fields
+}
+
+// WithContext returns a logger adding the fields stored in ctx and the id of
+// the trace ctx belongs to.
+func (l *Logger) WithContext(ctx context.Context) Logging {
+	entry := l.entry.WithFields(fieldsFrom(ctx))
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		entry = entry.WithField(TraceIDField, span.TraceID().String())
+	}
+
+	return &Logger{log: l.log, entry: entry}
+}
+
+func (l *Logger) WithFields(fields map[string]interface{}) Logging {
+	return &Logger{log: l.log, entry: l.entry.WithFields(fields)}
+}
@@ -3,17 +3,18 @@ package logger
 import (
 	"context"
 	"io"
-	"os"
-	"path"
+	"path/filepath"
 	"time"
 
+	"github.com/natefinch/lumberjack"
 	"github.com/sirupsen/logrus"
-)
 
-var logDir string
+	"quiz-service/init/config"
+)
 
 type Logger struct {
-	log *logrus.Logger
+	log   *logrus.Logger
+	entry *logrus.Entry
 }
 
 type Logging interface {
@@ -27,28 +28,37 @@ type Logging interface {
 	FatalF(format string, args ...interface{})
 	Panic(message string)
 	PanicF(format string, args ...interface{})
+	WithContext(ctx context.Context) Logging
+	WithFields(fields map[string]interface{}) Logging
 }
 
-func newLoggerFile() (*os.File, error) {
-	logFileName := time.Now().Format("2006-01-02") + ".log"
-	p := path.Join(logDir, logFileName)
-	logFile, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		return nil, err
-	}
-	return logFile, nil
-}
-
-func NewLogger(ctx context.Context, debug bool, dir string) (*Logger, error) {
+// NewLogger writes JSON lines to console and to <dir>/<base of dir>.log, e.g.
+// logs/http/http.log; a nil console keeps them in the file only, which the
+// admin commands use so their stdout carries nothing but results. Each logger
+// owns its file, so the three loggers never share rotation state. Secrets are
+// masked by the redaction hook before any line is written.
+func NewLogger(ctx context.Context, debug bool, file config.LogFile, console io.Writer) (*Logger, error) {
 	newLog := logrus.New()
-	logDir = dir
 
-	logFile, err := newLoggerFile()
-	if err != nil {
+	rotator := &lumberjack.Logger{
+		Filename:   filepath.Join(file.Dir, filepath.Base(file.Dir)+".log"),
+		MaxSize:    file.MaxSizeMB,
+		MaxBackups: file.MaxBackups,
+		MaxAge:     file.MaxAgeDays,
+		Compress:   file.Compress,
+		LocalTime:  true,
+	}
+	// open now, so a directory that cannot be written fails startup instead of
+	// the first log line
+	if _, err := rotator.Write(nil); err != nil {
 		return nil, err
 	}
-	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	newLog.SetOutput(multiWriter)
+	if console != nil {
+		newLog.SetOutput(io.MultiWriter(console, rotator))
+	} else {
+		newLog.SetOutput(rotator)
+	}
+	newLog.AddHook(newRedactHook())
 
 	if debug {
 		newLog.SetLevel(logrus.DebugLevel)
@@ -59,64 +69,66 @@ func NewLogger(ctx context.Context, debug bool, dir string) (*Logger, error) {
 	})
 	newLog.SetReportCaller(true)
 
-	ticker := time.NewTicker(24 * time.Hour)
-	go func() {
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				newLogFile, err := newLoggerFile()
-				if err != nil {
-					continue
-				}
-				logFile.Close()
-				newLog.SetOutput(newLogFile)
-				logFile = newLogFile
-			}
-		}
-	}()
+	go rotateDaily(ctx, rotator)
+
+	return &Logger{log: newLog, entry: logrus.NewEntry(newLog)}, nil
+}
 
-	return &Logger{newLog}, nil
+// rotateDaily starts a new file at every local midnight, on top of the size
+// limit, so each day's lines stay in their own files.
+func rotateDaily(ctx context.Context, rotator *lumberjack.Logger) {
+	for {
+		now := time.Now()
+		midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		timer := time.NewTimer(midnight.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			rotator.Close()
+			return
+		case <-timer.C:
+			rotator.Rotate()
+		}
+	}
 }
 
 func (l *Logger) Info(message string) {
-	l.log.Info(message)
+	l.entry.Info(message)
 }
 
 func (l *Logger) InfoF(format string, args ...interface{}) {
-	l.log.Infof(format, args...)
+	l.entry.Infof(format, args...)
 }
 
 func (l *Logger) Debug(message string) {
-	l.log.Debug(message)
+	l.entry.Debug(message)
 }
 
 func (l *Logger) DebugF(format string, args ...interface{}) {
-	l.log.Debugf(format, args...)
+	l.entry.Debugf(format, args...)
 }
 
 func (l *Logger) Error(message string) {
-	l.log.Error(message)
+	l.entry.Error(message)
 }
 
 func (l *Logger) ErrorF(format string, args ...interface{}) {
-	l.log.Errorf(format, args...)
+	l.entry.Errorf(format, args...)
 }
 
 func (l *Logger) Fatal(message string) {
-	l.log.Fatal(message)
+	l.entry.Fatal(message)
 }
 
 func (l *Logger) FatalF(format string, args ...interface{}) {
-	l.log.Fatalf(format, args...)
+	l.entry.Fatalf(format, args...)
 }
 
 func (l *Logger) Panic(message string) {
-	l.log.Panic(message)
+	l.entry.Panic(message)
 }
 
 func (l *Logger) PanicF(format string, args ...interface{}) {
-	l.log.Panicf(format, args...)
+	l.entry.Panicf(format, args...)
 }
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "***"
+
+// sensitiveKeys are masked wherever they show up: as log fields, in structs
+// formatted with %+v ("Password:secret") and in JSON ("password":"secret").
+// The uuid is the bearer credential of a session, so it is one of them.
+var sensitiveKeys = []string{"password", "password_salt", "secret", "token", "authorization", "uuid"}
+
+// sessionPath matches a uuid between slashes, where the deprecated routes
+// carry the session.
+var sessionPath = regexp.MustCompile(`(?i)/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}(/|$)`)
+
+var sensitiveValue = regexp.MustCompile(`(?i)("?(?:` + strings.Join(sensitiveKeys, "|") + `)"?\s*[:=]\s*)("[^"]*"|[^\s,}\]]+)`)
+
+// redactHook masks secrets in the message and the fields of every entry. Hooks
+// run before the formatter, so nothing unmasked reaches stdout or the files.
+type redactHook struct{}
+
+func newRedactHook() logrus.Hook {
+	return redactHook{}
+}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = sensitiveValue.ReplaceAllString(entry.Message, "${1}"+redacted)
+
+	for key, value := range entry.Data {
+		if sensitiveKey(key) {
+			entry.Data[key] = redacted
+			continue
+		}
+		if text, ok := value.(string); ok {
+			entry.Data[key] = sensitiveValue.ReplaceAllString(text, "${1}"+redacted)
+		}
+	}
+
+	return nil
+}
+
+func sensitiveKey(key string) bool {
+	for _, sensitive := range sensitiveKeys {
+		if strings.EqualFold(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactPath masks the session uuid in a request path.
+func redactPath(path string) string {
+	return sessionPath.ReplaceAllString(path, "/"+redacted+"${1}")
+}
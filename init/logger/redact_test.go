@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedactHook(t *testing.T) {
+	const session = "0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91"
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"struct password", "Login received | &{Login:bob Password:hunter2}", "Login received | &{Login:bob Password:***}"},
+		{"struct uuid", "Register success | &{UUID:" + session + " Login:bob}", "Register success | &{UUID:*** Login:bob}"},
+		{"json uuid", `{"uuid":"` + session + `","login":"bob"}`, `{"uuid":***,"login":"bob"}`},
+		{"json token", `{"token": "abc", "id": 1}`, `{"token": ***, "id": 1}`},
+		{"field style", "authorization=Bearer", "authorization=***"},
+		{"plain text", "Authenticated success | 7", "Authenticated success | 7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &logrus.Entry{Message: tt.message, Data: logrus.Fields{}}
+			if err := newRedactHook().Fire(entry); err != nil {
+				t.Fatal(err)
+			}
+			if entry.Message != tt.want {
+				t.Errorf("Fire() message = %q; want %q", entry.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHookFields(t *testing.T) {
+	entry := &logrus.Entry{Data: logrus.Fields{
+		"uuid":       "0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91",
+		"Password":   "hunter2",
+		"body":       `{"uuid":"0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91"}`,
+		"request_id": "0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91",
+		"user_id":    7,
+	}}
+	if err := newRedactHook().Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	want := logrus.Fields{
+		"uuid":       redacted,
+		"Password":   redacted,
+		"body":       `{"uuid":***}`,
+		"request_id": "0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91",
+		"user_id":    7,
+	}
+	for key, value := range want {
+		if entry.Data[key] != value {
+			t.Errorf("field %s = %v; want %v", key, entry.Data[key], value)
+		}
+	}
+}
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/quiz/0b6a1f5e-3c7d-4e2a-9f10-6d4c8b2e7a91/variant/list", "/quiz/***/variant/list"},
+		{"/quiz/0B6A1F5E-3C7D-4E2A-9F10-6D4C8B2E7A91", "/quiz/***"},
+		{"/quiz/api/v1/variants/math", "/quiz/api/v1/variants/math"},
+	}
+
+	for _, tt := range tests {
+		if got := redactPath(tt.path); got != tt.want {
+			t.Errorf("redactPath(%q) = %q; want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+const (
+	AccessCode   = "code"
+	AccessInvite = "invite"
+)
+
+type Access struct {
+	Id        int        `json:"id" db:"id"`
+	Kind      string     `json:"kind" binding:"omitempty,oneof=code invite" db:"kind"`
+	Code      string     `json:"code" db:"code"`
+	MaxUses   int        `json:"max_uses" binding:"min=0" db:"max_uses"`
+	Uses      int        `json:"uses" db:"uses"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type AccessRedeem struct {
+	Code string `json:"code" binding:"required,max=64"`
+}
@@ -1,5 +1,13 @@
 package entities
 
 type Answer struct {
-	Answer string `json:"answer" binding:"required"`
+	QuestionId int    `json:"-" db:"question_id"`
+	Answer     string `json:"answer" binding:"required"`
+	Feedback   string `json:"feedback,omitempty" binding:"max=255"`
+	Correct    bool   `json:"correct,omitempty"`
+}
+
+type UserAnswer struct {
+	Answer  string   `json:"answer" binding:"required_without=Answers"`
+	Answers []string `json:"answers" binding:"required_without=Answer"`
 }
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+type Certificate struct {
+	Code     string    `json:"code" db:"code"`
+	Login    string    `json:"login" db:"login"`
+	Variant  string    `json:"variant" db:"variant"`
+	Score    float64   `json:"score" db:"score"`
+	MaxScore float64   `json:"max_score" db:"max_score"`
+	IssuedAt time.Time `json:"issued_at" db:"issued_at"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "encoding/json"
+
+type GraphQuery struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphResult is the GraphQL response; errors do not change the http status.
+type GraphResult struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []*GraphError   `json:"errors,omitempty"`
+}
+
+type GraphError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
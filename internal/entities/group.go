@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+const (
+	ProgressNotStarted = "not_started"
+	ProgressInProgress = "in_progress"
+	ProgressCompleted  = "completed"
+)
+
+type Group struct {
+	Id          int                `json:"id" db:"id"`
+	OwnerId     int                `json:"owner_id" db:"owner_id"`
+	Name        string             `json:"name" binding:"required,max=50" db:"name"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	Members     []*GroupMember     `json:"members,omitempty"`
+	Assignments []*GroupAssignment `json:"assignments,omitempty"`
+}
+
+type GroupMember struct {
+	Login   string    `json:"login" db:"login"`
+	AddedAt time.Time `json:"added_at" db:"added_at"`
+}
+
+type GroupMembers struct {
+	Logins []string `json:"logins" binding:"required,min=1,dive,required"`
+}
+
+type GroupMembersResult struct {
+	Logins   []string `json:"logins"`
+	NotFound []string `json:"not_found,omitempty"`
+}
+
+type GroupAssignment struct {
+	VariantName string     `json:"variant_name" binding:"required" db:"variant_name"`
+	DueAt       *time.Time `json:"due_at,omitempty" db:"due_at"`
+	AssignedAt  time.Time  `json:"assigned_at" db:"assigned_at"`
+}
+
+type GroupProgress struct {
+	VariantName string     `json:"variant_name" db:"variant_name"`
+	DueAt       *time.Time `json:"due_at,omitempty" db:"due_at"`
+	Login       string     `json:"login" db:"login"`
+	Status      string     `json:"status" db:"-"`
+	Overdue     bool       `json:"overdue" db:"-"`
+	Score       float64    `json:"score" db:"score"`
+	MaxScore    float64    `json:"max_score" db:"max_score"`
+	StartAt     *time.Time `json:"start_at,omitempty" db:"start_at"`
+	FinishAt    *time.Time `json:"finish_at,omitempty" db:"finish_at"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+// Schema is the row golang-migrate keeps in schema_migrations. Dirty means a
+// migration failed halfway and the schema needs manual repair.
+type Schema struct {
+	Version uint `json:"version" db:"version"`
+	Dirty   bool `json:"dirty" db:"dirty"`
+}
+
+type Version struct {
+	Commit    string  `json:"commit"`
+	BuildTime string  `json:"build_time"`
+	Schema    *Schema `json:"schema,omitempty"`
+	Expected  uint    `json:"expected_schema_version"`
+}
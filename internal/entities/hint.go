@@ -0,0 +1,7 @@
+package entities
+
+type Hint struct {
+	Id      int     `json:"id" db:"id"`
+	Hint    string  `json:"hint" binding:"required,max=255" db:"hint"`
+	Penalty float64 `json:"penalty" binding:"min=0" db:"penalty"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+const (
+	LiveMessageJoined       = "joined"
+	LiveMessageParticipants = "participants"
+	LiveMessageQuestion     = "question"
+	LiveMessageAnswered     = "answered"
+	LiveMessageProgress     = "progress"
+	LiveMessageLeaderboard  = "leaderboard"
+	LiveMessageFinished     = "finished"
+	LiveMessageError        = "error"
+)
+
+type LiveSession struct {
+	Id              int        `json:"id" db:"id"`
+	VariantId       int        `json:"variant_id" db:"variant_id"`
+	HostId          int        `json:"host_id" db:"host_id"`
+	Pin             string     `json:"pin" db:"pin"`
+	QuestionSeconds int        `json:"question_seconds" db:"-"`
+	StartedAt       time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+type LiveOpen struct {
+	QuestionSeconds int `json:"question_seconds" binding:"omitempty,min=5,max=300"`
+}
+
+// LiveClient is one websocket connection to a live session. The session owns
+// Messages and closes it when the client leaves or the session finishes.
+type LiveClient struct {
+	Pin      string
+	UserId   int
+	Host     bool
+	Messages chan *LiveMessage
+}
+
+type LiveMessage struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+type LiveAnswer struct {
+	QuestionId int      `json:"question_id"`
+	Answer     string   `json:"answer"`
+	Answers    []string `json:"answers"`
+}
+
+type LiveQuestion struct {
+	Index    int       `json:"index"`
+	Total    int       `json:"total"`
+	Question *Question `json:"question"`
+	Deadline time.Time `json:"deadline"`
+}
+
+type LiveAnswerResult struct {
+	QuestionId int     `json:"question_id"`
+	Correct    bool    `json:"correct"`
+	Points     float64 `json:"points"`
+	Bonus      float64 `json:"bonus"`
+}
+
+type LiveScore struct {
+	Rank           int     `json:"rank" db:"rank"`
+	UserId         int     `json:"-" db:"user_id"`
+	Login          string  `json:"login" db:"login"`
+	Score          float64 `json:"score" db:"score"`
+	CorrectAnswers int     `json:"correct_answers" db:"correct_answers"`
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+const DefaultOrganization = "default"
+
+type Organization struct {
+	Id         int       `json:"id" db:"id"`
+	Name       string    `json:"name" binding:"required,max=50" db:"name"`
+	InviteCode string    `json:"invite_code,omitempty" db:"invite_code"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type Practice struct {
+	ID             int        `json:"id"`
+	UserId         int        `json:"user_id" db:"user_id"`
+	VariantId      int        `json:"variant_id" db:"variant_id"`
+	CorrectAnswers int        `json:"correct_answers" db:"correct_answers"`
+	Attempts       int        `json:"attempts" db:"attempts"`
+	StartAt        time.Time  `json:"start_at" db:"start_at"`
+	FinishAt       *time.Time `json:"finish_at" db:"finish_at"`
+}
+
+type PracticeFeedback struct {
+	Correct     bool     `json:"correct"`
+	Answers     []string `json:"answers,omitempty"`
+	Explanation string   `json:"explanation,omitempty"`
+	Feedback    []string `json:"feedback,omitempty"`
+}
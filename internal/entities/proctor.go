@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+const (
+	ProctorStarted  = "started"
+	ProctorAnswered = "answered"
+	ProctorFinished = "finished"
+)
+
+// ProctorEvent is a step of an attempt as a proctor sees it: who did what and
+// when, never the answer itself.
+type ProctorEvent struct {
+	Id         int64     `json:"id"`
+	Type       string    `json:"type"`
+	VariantId  int       `json:"variant_id"`
+	UserId     int       `json:"user_id"`
+	Login      string    `json:"login"`
+	QuestionId int       `json:"question_id,omitempty"`
+	At         time.Time `json:"at"`
+}
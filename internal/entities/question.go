@@ -3,10 +3,16 @@ package entities
 import "encoding/json"
 
 type Question struct {
-	Id       int       `json:"id" db:"id"`
-	Question string    `json:"question" binding:"required,max=50" db:"question"`
-	Answer   string    `json:"answer" binding:"required,max=50" db:"answer"`
-	Answers  []*Answer `json:"answers" binding:"required,len=3"`
+	Id             int       `json:"id" db:"id"`
+	VariantId      int       `json:"-" db:"variant_id"`
+	Question       string    `json:"question" binding:"required,max=50" db:"question"`
+	Answer         string    `json:"answer" binding:"required,max=50" db:"answer"`
+	Explanation    string    `json:"explanation,omitempty" binding:"max=255" db:"explanation"`
+	Points         *float64  `json:"points" binding:"omitempty,min=0" db:"points"`
+	NegativePoints float64   `json:"negative_points" binding:"min=0" db:"negative_points"`
+	Multiple       bool      `json:"multiple" db:"multiple"`
+	Answers        []*Answer `json:"answers" binding:"required,len=3,dive"`
+	Hints          []*Hint   `json:"hints,omitempty" binding:"omitempty,dive"`
 }
 
 type QuestionRemove struct {
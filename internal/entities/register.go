@@ -2,10 +2,18 @@ package entities
 
 import "time"
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type Register struct {
-	UUID     string `json:"uuid"`
-	Login    string `json:"login" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	UUID           string `json:"uuid"`
+	Login          string `json:"login" binding:"required"`
+	Password       string `json:"password" binding:"required"`
+	Invite         string `json:"invite" binding:"max=32"`
+	Organization   string `json:"-"`
+	OrganizationId int    `json:"-"`
 }
 
 type Login struct {
@@ -14,10 +22,12 @@ type Login struct {
 }
 
 type User struct {
-	ID           int        `json:"id"`
-	UUID         string     `json:"uuid"`
-	Login        string     `json:"login"`
-	Authorized   bool       `json:"authorized"`
-	AuthorizedAt time.Time  `json:"authorized_at" db:"authorized_at"`
-	QuitAt       *time.Time `json:"quit_at,omitempty" db:"quit_at"`
+	ID             int        `json:"id"`
+	OrganizationId int        `json:"organization_id" db:"organization_id"`
+	UUID           string     `json:"uuid"`
+	Login          string     `json:"login"`
+	Authorized     bool       `json:"authorized"`
+	AuthorizedAt   time.Time  `json:"authorized_at" db:"authorized_at"`
+	QuitAt         *time.Time `json:"quit_at,omitempty" db:"quit_at"`
+	Role           string     `json:"role"`
 }
@@ -0,0 +1,12 @@
+package entities
+
+type Review struct {
+	QuestionId    int     `json:"question_id" db:"question_id"`
+	Question      string  `json:"question" db:"question"`
+	Answer        string  `json:"answer" db:"answer"`
+	Correct       bool    `json:"correct" db:"correct"`
+	Points        float64 `json:"points" db:"points"`
+	CorrectAnswer string  `json:"correct_answer,omitempty" db:"correct_answer"`
+	Explanation   string  `json:"explanation,omitempty" db:"explanation"`
+	Feedback      string  `json:"feedback,omitempty" db:"feedback"`
+}
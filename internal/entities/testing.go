@@ -5,8 +5,13 @@ import "time"
 type Testing struct {
 	ID             int        `json:"id"`
 	UserId         int        `json:"user_id" db:"user_id"`
+	Login          string     `json:"login,omitempty" db:"login"`
 	VariantId      int        `json:"variant_id" db:"variant_id"`
 	CorrectAnswers int        `json:"correct_answers" db:"correct_answers"`
+	Penalty        float64    `json:"penalty" db:"penalty"`
+	Score          float64    `json:"score" db:"score"`
+	MaxScore       float64    `json:"max_score" db:"max_score"`
 	StartAt        time.Time  `json:"start_at" db:"start_at"`
 	FinishAt       *time.Time `json:"finish_at" db:"finish_at"`
+	Passed         bool       `json:"passed" db:"-"`
 }
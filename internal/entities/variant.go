@@ -1,9 +1,37 @@
 package entities
 
+import "time"
+
+const (
+	VariantUpcoming = "upcoming"
+	VariantOpen     = "open"
+	VariantClosed   = "closed"
+)
+
 type Variant struct {
-	Id        int         `json:"id"`
-	Name      string      `json:"name" binding:"required"`
-	Questions []*Question `json:"questions"`
+	Id                 int         `json:"id"`
+	OrganizationId     int         `json:"organization_id" db:"organization_id"`
+	AuthorId           *int        `json:"author_id,omitempty" db:"author_id"`
+	Name               string      `json:"name" binding:"required"`
+	RevealAnswers      bool        `json:"reveal_answers" db:"reveal_answers"`
+	RevealExplanations bool        `json:"reveal_explanations" db:"reveal_explanations"`
+	PassMark           float64     `json:"pass_mark" binding:"min=0,max=100" db:"pass_mark"`
+	OpensAt            *time.Time  `json:"opens_at,omitempty" db:"opens_at"`
+	ClosesAt           *time.Time  `json:"closes_at,omitempty" db:"closes_at"`
+	Restricted         bool        `json:"restricted" db:"restricted"`
+	Practice           bool        `json:"practice" db:"practice"`
+	Status             string      `json:"status,omitempty" db:"-"`
+	Questions          []*Question `json:"questions"`
+}
+
+type VariantSettings struct {
+	RevealAnswers      bool       `json:"reveal_answers"`
+	RevealExplanations bool       `json:"reveal_explanations"`
+	PassMark           float64    `json:"pass_mark" binding:"min=0,max=100"`
+	OpensAt            *time.Time `json:"opens_at"`
+	ClosesAt           *time.Time `json:"closes_at"`
+	Restricted         bool       `json:"restricted"`
+	Practice           bool       `json:"practice"`
 }
 
 type Results struct {
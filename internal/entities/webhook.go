@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookAttemptFinished  = "attempt.finished"
+	WebhookVariantPublished = "variant.published"
+	WebhookUserRegistered   = "user.registered"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+type Webhook struct {
+	Id        int       `json:"id" db:"id"`
+	Url       string    `json:"url" binding:"required,url,max=255" db:"url"`
+	Secret    string    `json:"secret,omitempty" binding:"max=255" db:"secret"`
+	Events    []string  `json:"events" binding:"required,min=1,dive,oneof=attempt.finished variant.published user.registered"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type WebhookDelivery struct {
+	Id            int64      `json:"id" db:"id"`
+	WebhookId     int        `json:"webhook_id" db:"webhook_id"`
+	Event         string     `json:"event" db:"event"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	StatusCode    *int       `json:"status_code,omitempty" db:"status_code"`
+	Error         *string    `json:"error,omitempty" db:"error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// WebhookCall is a due delivery together with everything needed to send it.
+type WebhookCall struct {
+	DeliveryId int64     `db:"delivery_id"`
+	Attempts   int       `db:"attempts"`
+	Url        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	OutboxId   int64     `db:"outbox_id"`
+	Event      string    `db:"event"`
+	Payload    []byte    `db:"payload"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// WebhookPayload is the body POSTed to a webhook url.
+type WebhookPayload struct {
+	Id        int64           `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"quiz-service/internal/entities"
+	"sync"
+)
+
+const (
+	historySize    = 256
+	subscribeQueue = 64
+)
+
+// Publisher is what the service layer sees of the broker.
+type Publisher interface {
+	Publish(event *entities.ProctorEvent)
+}
+
+// Broker fans proctor events out to the subscribers of a variant and keeps the
+// latest events of every variant, so a subscriber that reconnects with
+// Last-Event-ID gets what it missed.
+type Broker struct {
+	mu sync.Mutex
+
+	lastId      int64
+	history     map[int][]*entities.ProctorEvent
+	subscribers map[int]map[chan *entities.ProctorEvent]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		history:     make(map[int][]*entities.ProctorEvent),
+		subscribers: make(map[int]map[chan *entities.ProctorEvent]struct{}),
+	}
+}
+
+func (b *Broker) Publish(event *entities.ProctorEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastId++
+	event.Id = b.lastId
+
+	history := append(b.history[event.VariantId], event)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	b.history[event.VariantId] = history
+
+	for ch := range b.subscribers[event.VariantId] {
+		select {
+		case ch <- event:
+		default:
+			// A subscriber that can't keep up is cut off; it reconnects with
+			// Last-Event-ID and catches up from the history.
+			delete(b.subscribers[event.VariantId], ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns the retained events newer than lastEventId and a channel of
+// the following ones. The channel is closed by cancel or when the subscriber lags behind.
+func (b *Broker) Subscribe(variantId int, lastEventId int64) ([]*entities.ProctorEvent, <-chan *entities.ProctorEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []*entities.ProctorEvent
+	for _, event := range b.history[variantId] {
+		if event.Id > lastEventId {
+			backlog = append(backlog, event)
+		}
+	}
+
+	ch := make(chan *entities.ProctorEvent, subscribeQueue)
+	if b.subscribers[variantId] == nil {
+		b.subscribers[variantId] = make(map[chan *entities.ProctorEvent]struct{})
+	}
+	b.subscribers[variantId][ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[variantId][ch]; ok {
+			delete(b.subscribers[variantId], ch)
+			close(ch)
+		}
+		if len(b.subscribers[variantId]) == 0 {
+			delete(b.subscribers, variantId)
+		}
+	}
+
+	return backlog, ch, cancel
+}
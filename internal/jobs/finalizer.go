@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"quiz-service/init/logger"
+	"quiz-service/internal/service"
+	"time"
+)
+
+// Finalizer periodically closes attempts that are still open after their
+// variant's closes_at, computing scores and certificates as /results would.
+type Finalizer struct {
+	service  service.VariantService
+	interval time.Duration
+
+	log logger.Logging
+}
+
+func NewFinalizer(service service.VariantService, interval time.Duration, log logger.Logging) *Finalizer {
+	return &Finalizer{service: service, interval: interval, log: log}
+}
+
+func (f *Finalizer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			finalized, err := f.service.VariantFinalizeExpired(ctx)
+			if err != nil {
+				f.log.ErrorF("Finalizer failed: %v", err)
+				continue
+			}
+			if finalized > 0 {
+				f.log.InfoF("Finalizer closed %d expired attempts", finalized)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"quiz-service/init/logger"
+	"quiz-service/internal/service"
+	"time"
+)
+
+// Reaper periodically finishes live sessions left without their host, saving
+// the leaderboard as LiveFinish would.
+type Reaper struct {
+	service  service.LiveService
+	interval time.Duration
+
+	log logger.Logging
+}
+
+func NewReaper(service service.LiveService, interval time.Duration, log logger.Logging) *Reaper {
+	return &Reaper{service: service, interval: interval, log: log}
+}
+
+func (r *Reaper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			expired, err := r.service.LiveExpireIdle(ctx)
+			if err != nil {
+				r.log.ErrorF("Reaper failed: %v", err)
+				continue
+			}
+			if expired > 0 {
+				r.log.InfoF("Reaper finished %d idle live sessions", expired)
+			}
+		}
+	}
+}
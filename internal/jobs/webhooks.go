@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"quiz-service/init/logger"
+	"quiz-service/internal/service"
+	"time"
+)
+
+// Dispatcher periodically delivers webhook events written to the outbox,
+// retrying failed deliveries with exponential backoff.
+type Dispatcher struct {
+	service  service.WebhooksService
+	interval time.Duration
+
+	log logger.Logging
+}
+
+func NewDispatcher(service service.WebhooksService, interval time.Duration, log logger.Logging) *Dispatcher {
+	return &Dispatcher{service: service, interval: interval, log: log}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			attempted, err := d.service.WebhookDispatch(ctx)
+			if err != nil {
+				d.log.ErrorF("Dispatcher failed: %v", err)
+				continue
+			}
+			if attempted > 0 {
+				d.log.InfoF("Dispatcher attempted %d webhook deliveries", attempted)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const namespace = "quiz"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by gin route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by gin route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	QueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Repository method latency, including every query the method runs.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	AttemptsStarted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "attempts_started_total",
+		Help:      "Tests started by users.",
+	})
+
+	AttemptsFinished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "attempts_finished_total",
+		Help:      "Tests finished, by the user or by the deadline.",
+	})
+
+	AnswersAccepted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "answers_accepted_total",
+		Help:      "Answers accepted during tests by correctness.",
+	}, []string{"correct"})
+
+	Registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Users registered.",
+	})
+)
+
+// RegisterDB exposes the pool stats of db: open, in use and idle connections,
+// waits and closes.
+func RegisterDB(db *sqlx.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db.DB, namespace))
+}
+
+// ObserveQuery starts timing a repository method; the returned func records
+// the duration, so it is meant to be deferred:
+//
+//	defer metrics.ObserveQuery("variants", "VariantAdd")()
+func ObserveQuery(repository, method string) func() {
+	start := time.Now()
+	return func() {
+		QueryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+type Access struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewAccess(db *sqlx.DB, logger logger.Logging) *Access {
+	return &Access{db: db, logger: logger}
+}
+
+func (a *Access) AccessAdd(ctx context.Context, tenantId, variantId int, access *entities.Access) (*entities.Access, error) {
+	a.logger.WithContext(ctx).InfoF("AccessAdd received | %d | %d | %s", tenantId, variantId, access.Kind)
+	ctx, done := operation(ctx, "access", "AccessAdd")
+	defer done()
+
+	var accessEntity = new(entities.Access)
+	query := `
+		INSERT INTO access_codes (variant_id, kind, code, max_uses, expires_at)
+		SELECT id, $2, $3, $4, $5 FROM variants WHERE id = $1 AND organization_id = $6
+		RETURNING id, kind, code, max_uses, uses, expires_at, created_at;
+	`
+	if err := a.db.GetContext(ctx, accessEntity, query, variantId, access.Kind, access.Code, access.MaxUses, access.ExpiresAt, tenantId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorVariantNotFound
+		}
+		return nil, err
+	}
+
+	a.logger.WithContext(ctx).InfoF("AccessAdd success | %d | %d | %s", tenantId, variantId, access.Kind)
+
+	return accessEntity, nil
+}
+
+func (a *Access) AccessList(ctx context.Context, tenantId, variantId int) ([]*entities.Access, error) {
+	a.logger.WithContext(ctx).InfoF("AccessList received | %d | %d", tenantId, variantId)
+	ctx, done := operation(ctx, "access", "AccessList")
+	defer done()
+
+	var accesses = make([]*entities.Access, 0)
+	query := `
+		SELECT ac.id, ac.kind, ac.code, ac.max_uses, ac.uses, ac.expires_at, ac.created_at
+		FROM access_codes ac
+			JOIN variants v ON v.id = ac.variant_id
+		WHERE ac.variant_id = $1 AND v.organization_id = $2
+		ORDER BY ac.id
+	`
+	if err := a.db.SelectContext(ctx, &accesses, query, variantId, tenantId); err != nil {
+		return nil, err
+	}
+
+	a.logger.WithContext(ctx).InfoF("AccessList success | %d | %d", tenantId, variantId)
+
+	return accesses, nil
+}
+
+func (a *Access) AccessRemove(ctx context.Context, tenantId, variantId, accessId int) (int64, error) {
+	a.logger.WithContext(ctx).InfoF("AccessRemove received | %d | %d | %d", tenantId, variantId, accessId)
+	ctx, done := operation(ctx, "access", "AccessRemove")
+	defer done()
+
+	query := `
+		DELETE FROM access_codes
+		WHERE id = $1 AND variant_id = $2
+			AND variant_id IN (SELECT id FROM variants WHERE organization_id = $3)
+	`
+	res, err := a.db.ExecContext(ctx, query, accessId, variantId, tenantId)
+	if err != nil {
+		return 0, err
+	}
+
+	a.logger.WithContext(ctx).InfoF("AccessRemove success | %d | %d | %d", tenantId, variantId, accessId)
+
+	return res.RowsAffected()
+}
+
+func (a *Access) AccessRedeem(ctx context.Context, tenantId, variantId, userId int, code string) error {
+	a.logger.WithContext(ctx).InfoF("AccessRedeem received | %d | %d | %d", tenantId, variantId, userId)
+	ctx, done := operation(ctx, "access", "AccessRedeem")
+	defer done()
+
+	tx, err := a.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	var accessEntity = new(entities.Access)
+	selectQuery := `
+		SELECT ac.id, ac.kind, ac.code, ac.max_uses, ac.uses, ac.expires_at, ac.created_at
+		FROM access_codes ac
+			JOIN variants v ON v.id = ac.variant_id
+		WHERE ac.variant_id = $1 AND ac.code = $2 AND v.organization_id = $3
+		FOR UPDATE OF ac
+	`
+	if err := tx.GetContext(ctx, accessEntity, selectQuery, variantId, code, tenantId); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return constants.ErrorAccessNotFound
+		}
+		return err
+	}
+
+	if accessEntity.ExpiresAt != nil && !time.Now().Before(*accessEntity.ExpiresAt) {
+		tx.Rollback()
+		return constants.ErrorAccessExpired
+	}
+	if accessEntity.MaxUses > 0 && accessEntity.Uses >= accessEntity.MaxUses {
+		tx.Rollback()
+		return constants.ErrorAccessExhausted
+	}
+
+	assignQuery := `
+		INSERT INTO variant_assignments (variant_id, user_id, access_code_id) VALUES ($1, $2, $3)
+		ON CONFLICT (variant_id, user_id) DO NOTHING
+	`
+	res, err := tx.ExecContext(ctx, assignQuery, variantId, userId, accessEntity.Id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if num, _ := res.RowsAffected(); num > 0 {
+		usesQuery := `
+			UPDATE access_codes SET uses = uses + 1 WHERE id = $1
+		`
+		if _, err := tx.ExecContext(ctx, usesQuery, accessEntity.Id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	a.logger.WithContext(ctx).InfoF("AccessRedeem success | %d | %d | %d", tenantId, variantId, userId)
+
+	return nil
+}
+
+func (a *Access) AccessAllowed(ctx context.Context, tenantId, variantId, userId int) (bool, error) {
+	a.logger.WithContext(ctx).InfoF("AccessAllowed received | %d | %d | %d", tenantId, variantId, userId)
+	ctx, done := operation(ctx, "access", "AccessAllowed")
+	defer done()
+
+	var allowed bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM variants WHERE id = $1 AND organization_id = $3
+		) AND (
+			EXISTS (
+				SELECT 1 FROM variant_assignments WHERE variant_id = $1 AND user_id = $2
+			) OR EXISTS (
+				SELECT 1
+				FROM group_assignments ga
+					JOIN group_members gm ON gm.group_id = ga.group_id
+				WHERE ga.variant_id = $1 AND gm.user_id = $2
+			)
+		)
+	`
+	if err := a.db.GetContext(ctx, &allowed, query, variantId, userId, tenantId); err != nil {
+		return false, err
+	}
+
+	a.logger.WithContext(ctx).InfoF("AccessAllowed success | %d | %d | %d", tenantId, variantId, userId)
+
+	return allowed, nil
+}
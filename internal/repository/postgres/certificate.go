@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+)
+
+const certificateSelect = `
+	SELECT c.code, c.issued_at, a.login, v.name AS variant, t.score, t.max_score
+	FROM certificates c
+		JOIN testing t ON t.id = c.test_id
+		JOIN auth a ON a.id = t.user_id
+		JOIN variants v ON v.id = t.variant_id
+`
+
+type Certificate struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewCertificate(db *sqlx.DB, logger logger.Logging) *Certificate {
+	return &Certificate{db: db, logger: logger}
+}
+
+func (c *Certificate) CertificateIssue(ctx context.Context, tenantId, testId int, code string) (*entities.Certificate, error) {
+	c.logger.WithContext(ctx).InfoF("CertificateIssue received | %d | %d", tenantId, testId)
+	ctx, done := operation(ctx, "certificate", "CertificateIssue")
+	defer done()
+
+	query := `
+		INSERT INTO certificates (test_id, code)
+		SELECT t.id, $2
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE t.id = $1 AND v.organization_id = $3
+		ON CONFLICT (test_id) DO NOTHING
+	`
+	if _, err := c.db.ExecContext(ctx, query, testId, code, tenantId); err != nil {
+		return nil, err
+	}
+
+	certificateEntity, err := c.CertificateGet(ctx, tenantId, testId)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.WithContext(ctx).InfoF("CertificateIssue success | %d | %d", tenantId, testId)
+
+	return certificateEntity, nil
+}
+
+func (c *Certificate) CertificateGet(ctx context.Context, tenantId, testId int) (*entities.Certificate, error) {
+	c.logger.WithContext(ctx).InfoF("CertificateGet received | %d | %d", tenantId, testId)
+	ctx, done := operation(ctx, "certificate", "CertificateGet")
+	defer done()
+
+	var certificateEntity = new(entities.Certificate)
+	query := certificateSelect + `WHERE c.test_id = $1 AND v.organization_id = $2`
+	if err := c.db.GetContext(ctx, certificateEntity, query, testId, tenantId); err != nil {
+		return nil, err
+	}
+
+	c.logger.WithContext(ctx).InfoF("CertificateGet success | %d | %d", tenantId, testId)
+
+	return certificateEntity, nil
+}
+
+// CertificateVerify backs the public verification page, so it looks the code up across every organization.
+func (c *Certificate) CertificateVerify(ctx context.Context, code string) (*entities.Certificate, error) {
+	c.logger.WithContext(ctx).InfoF("CertificateVerify received | %s", code)
+	ctx, done := operation(ctx, "certificate", "CertificateVerify")
+	defer done()
+
+	var certificateEntity = new(entities.Certificate)
+	query := certificateSelect + `WHERE c.code = $1`
+	if err := c.db.GetContext(ctx, certificateEntity, query, code); err != nil {
+		return nil, err
+	}
+
+	c.logger.WithContext(ctx).InfoF("CertificateVerify success | %s", code)
+
+	return certificateEntity, nil
+}
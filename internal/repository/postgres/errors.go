@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"quiz-service/pkg/constants"
+)
+
+// SQLSTATE codes the repositories react to,
+// see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	uniqueViolation          = "23505"
+	stringDataRightTruncated = "22001"
+)
+
+// uniqueErrors names the domain error behind each unique constraint. The names
+// are the ones Postgres generates for the constraints in migrations.
+var uniqueErrors = map[string]error{
+	"auth_login_key":                    constants.ErrorUserAlreadyExists,
+	"organizations_name_key":            constants.ErrorOrganizationAlreadyExists,
+	"variants_organization_id_name_key": constants.ErrorVariantAlreadyExists,
+	"questions_variant_id_question_key": constants.ErrorQuestionAlreadyExists,
+	"testing_user_id_variant_id_key":    constants.ErrorTestAlreadyStarted,
+	"groups_owner_id_name_key":          constants.ErrorGroupAlreadyExists,
+}
+
+// translate replaces a unique violation of a known constraint with its domain
+// error. Any other error is returned as is.
+func translate(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if pgErr.Code == uniqueViolation {
+		if domainErr, ok := uniqueErrors[pgErr.ConstraintName]; ok {
+			return domainErr
+		}
+	}
+
+	return err
+}
+
+// hasCode reports whether err is a Postgres error with the given SQLSTATE.
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+)
+
+// Graph loads whole batches of rows by key for the GraphQL loaders, one query
+// per batch instead of one per parent row.
+type Graph struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewGraph(db *sqlx.DB, logger logger.Logging) *Graph {
+	return &Graph{db: db, logger: logger}
+}
+
+func (g *Graph) GraphVariants(ctx context.Context, tenantId int) ([]*entities.Variant, error) {
+	g.logger.WithContext(ctx).InfoF("GraphVariants received | %d", tenantId)
+	ctx, done := operation(ctx, "graph", "GraphVariants")
+	defer done()
+
+	var variants = make([]*entities.Variant, 0)
+	query := `
+		SELECT id, organization_id, author_id, name, reveal_answers, reveal_explanations, pass_mark, opens_at, closes_at, restricted, practice
+		FROM variants
+		WHERE organization_id = $1
+		ORDER BY id;
+	`
+	if err := g.db.SelectContext(ctx, &variants, query, tenantId); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphVariants success | %d", tenantId)
+
+	return variants, nil
+}
+
+func (g *Graph) GraphVariantsByIds(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Variant, error) {
+	g.logger.WithContext(ctx).InfoF("GraphVariantsByIds received | %d | %v", tenantId, variantIds)
+	ctx, done := operation(ctx, "graph", "GraphVariantsByIds")
+	defer done()
+
+	var variants = make([]*entities.Variant, 0)
+	query := `
+		SELECT id, organization_id, author_id, name, reveal_answers, reveal_explanations, pass_mark, opens_at, closes_at, restricted, practice
+		FROM variants
+		WHERE organization_id = $1 AND id = ANY($2);
+	`
+	if err := g.db.SelectContext(ctx, &variants, query, tenantId, variantIds); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphVariantsByIds success | %d", tenantId)
+
+	return variants, nil
+}
+
+func (g *Graph) GraphQuestions(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Question, error) {
+	g.logger.WithContext(ctx).InfoF("GraphQuestions received | %d | %v", tenantId, variantIds)
+	ctx, done := operation(ctx, "graph", "GraphQuestions")
+	defer done()
+
+	var questions = make([]*entities.Question, 0)
+	query := `
+		SELECT q.id, q.variant_id, q.question, q.answer, q.explanation, q.points, q.negative_points, q.multiple
+		FROM questions q
+			JOIN variants v ON v.id = q.variant_id
+		WHERE v.organization_id = $1 AND q.variant_id = ANY($2)
+		ORDER BY q.id;
+	`
+	if err := g.db.SelectContext(ctx, &questions, query, tenantId, variantIds); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphQuestions success | %d", tenantId)
+
+	return questions, nil
+}
+
+func (g *Graph) GraphAnswers(ctx context.Context, tenantId int, questionIds []int) ([]*entities.Answer, error) {
+	g.logger.WithContext(ctx).InfoF("GraphAnswers received | %d | %v", tenantId, questionIds)
+	ctx, done := operation(ctx, "graph", "GraphAnswers")
+	defer done()
+
+	var answers = make([]*entities.Answer, 0)
+	query := `
+		SELECT qaa.questions_id AS question_id, a.answer
+		FROM questions_and_answers qaa
+			JOIN answers a ON a.id = qaa.answers_id
+			JOIN questions q ON q.id = qaa.questions_id
+			JOIN variants v ON v.id = q.variant_id
+		WHERE v.organization_id = $1 AND qaa.questions_id = ANY($2)
+		ORDER BY qaa.questions_id, a.id;
+	`
+	if err := g.db.SelectContext(ctx, &answers, query, tenantId, questionIds); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphAnswers success | %d", tenantId)
+
+	return answers, nil
+}
+
+func (g *Graph) GraphAttempts(ctx context.Context, tenantId int, userIds []int) ([]*entities.Testing, error) {
+	g.logger.WithContext(ctx).InfoF("GraphAttempts received | %d | %v", tenantId, userIds)
+	ctx, done := operation(ctx, "graph", "GraphAttempts")
+	defer done()
+
+	var attempts = make([]*entities.Testing, 0)
+	query := `
+		SELECT t.id, t.user_id, t.variant_id, t.correct_answers, t.penalty, t.score, t.max_score, t.start_at, t.finish_at
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE v.organization_id = $1 AND t.user_id = ANY($2)
+		ORDER BY t.id;
+	`
+	if err := g.db.SelectContext(ctx, &attempts, query, tenantId, userIds); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphAttempts success | %d", tenantId)
+
+	return attempts, nil
+}
+
+func (g *Graph) GraphUsers(ctx context.Context, tenantId int, userIds []int) ([]*entities.User, error) {
+	g.logger.WithContext(ctx).InfoF("GraphUsers received | %d | %v", tenantId, userIds)
+	ctx, done := operation(ctx, "graph", "GraphUsers")
+	defer done()
+
+	var users = make([]*entities.User, 0)
+	query := `
+		SELECT id, organization_id, login
+		FROM auth
+		WHERE organization_id = $1 AND id = ANY($2);
+	`
+	if err := g.db.SelectContext(ctx, &users, query, tenantId, userIds); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GraphUsers success | %d", tenantId)
+
+	return users, nil
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"time"
+)
+
+type Groups struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewGroups(db *sqlx.DB, logger logger.Logging) *Groups {
+	return &Groups{db: db, logger: logger}
+}
+
+func (g *Groups) GroupAdd(ctx context.Context, tenantId, ownerId int, name string) (*entities.Group, error) {
+	g.logger.WithContext(ctx).InfoF("GroupAdd received | %d | %d | %s", tenantId, ownerId, name)
+	ctx, done := operation(ctx, "groups", "GroupAdd")
+	defer done()
+
+	var groupEntity = new(entities.Group)
+	query := `
+		INSERT INTO groups (organization_id, owner_id, name) VALUES ($1, $2, $3)
+		RETURNING id, owner_id, name, created_at;
+	`
+	if err := g.db.GetContext(ctx, groupEntity, query, tenantId, ownerId, name); err != nil {
+		return nil, translate(err)
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupAdd success | %d | %d | %s", tenantId, ownerId, name)
+
+	return groupEntity, nil
+}
+
+func (g *Groups) GroupList(ctx context.Context, tenantId, ownerId int) ([]*entities.Group, error) {
+	g.logger.WithContext(ctx).InfoF("GroupList received | %d | %d", tenantId, ownerId)
+	ctx, done := operation(ctx, "groups", "GroupList")
+	defer done()
+
+	var groups = make([]*entities.Group, 0)
+	query := `
+		SELECT id, owner_id, name, created_at FROM groups WHERE organization_id = $1 AND owner_id = $2 ORDER BY id
+	`
+	if err := g.db.SelectContext(ctx, &groups, query, tenantId, ownerId); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupList success | %d | %d", tenantId, ownerId)
+
+	return groups, nil
+}
+
+func (g *Groups) GroupGet(ctx context.Context, tenantId, groupId int) (*entities.Group, error) {
+	g.logger.WithContext(ctx).InfoF("GroupGet received | %d | %d", tenantId, groupId)
+	ctx, done := operation(ctx, "groups", "GroupGet")
+	defer done()
+
+	var groupEntity = new(entities.Group)
+	query := `
+		SELECT id, owner_id, name, created_at FROM groups WHERE organization_id = $1 AND id = $2
+	`
+	if err := g.db.GetContext(ctx, groupEntity, query, tenantId, groupId); err != nil {
+		return nil, err
+	}
+
+	membersQuery := `
+		SELECT a.login, gm.added_at
+		FROM group_members gm
+			JOIN auth a ON a.id = gm.user_id
+		WHERE gm.group_id = $1
+		ORDER BY a.login
+	`
+	if err := g.db.SelectContext(ctx, &groupEntity.Members, membersQuery, groupId); err != nil {
+		return nil, err
+	}
+
+	assignmentsQuery := `
+		SELECT v.name AS variant_name, ga.due_at, ga.assigned_at
+		FROM group_assignments ga
+			JOIN variants v ON v.id = ga.variant_id
+		WHERE ga.group_id = $1
+		ORDER BY ga.assigned_at
+	`
+	if err := g.db.SelectContext(ctx, &groupEntity.Assignments, assignmentsQuery, groupId); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupGet success | %d | %d", tenantId, groupId)
+
+	return groupEntity, nil
+}
+
+func (g *Groups) GroupRemove(ctx context.Context, tenantId, groupId int) (int64, error) {
+	g.logger.WithContext(ctx).InfoF("GroupRemove received | %d | %d", tenantId, groupId)
+	ctx, done := operation(ctx, "groups", "GroupRemove")
+	defer done()
+
+	query := `
+		DELETE FROM groups WHERE organization_id = $1 AND id = $2
+	`
+	res, err := g.db.ExecContext(ctx, query, tenantId, groupId)
+	if err != nil {
+		return 0, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupRemove success | %d | %d", tenantId, groupId)
+
+	return res.RowsAffected()
+}
+
+func (g *Groups) GroupMembersAdd(ctx context.Context, tenantId, groupId int, logins []string) ([]string, error) {
+	g.logger.WithContext(ctx).InfoF("GroupMembersAdd received | %d | %d | %v", tenantId, groupId, logins)
+	ctx, done := operation(ctx, "groups", "GroupMembersAdd")
+	defer done()
+
+	tx, err := g.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+
+	var found = make([]string, 0, len(logins))
+	selectQuery := `
+		SELECT login FROM auth WHERE organization_id = $1 AND login = ANY($2)
+	`
+	if err := tx.SelectContext(ctx, &found, selectQuery, tenantId, logins); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	insertQuery := `
+		INSERT INTO group_members (group_id, user_id)
+		SELECT g.id, a.id
+		FROM groups g
+			JOIN auth a ON a.organization_id = g.organization_id
+		WHERE g.id = $1 AND g.organization_id = $2 AND a.login = ANY($3)
+		ON CONFLICT (group_id, user_id) DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, groupId, tenantId, logins); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupMembersAdd success | %d | %d | %v", tenantId, groupId, found)
+
+	return found, nil
+}
+
+func (g *Groups) GroupMembersRemove(ctx context.Context, tenantId, groupId int, logins []string) (int64, error) {
+	g.logger.WithContext(ctx).InfoF("GroupMembersRemove received | %d | %d | %v", tenantId, groupId, logins)
+	ctx, done := operation(ctx, "groups", "GroupMembersRemove")
+	defer done()
+
+	query := `
+		DELETE FROM group_members
+		WHERE group_id = $1
+			AND group_id IN (SELECT id FROM groups WHERE organization_id = $2)
+			AND user_id IN (SELECT id FROM auth WHERE organization_id = $2 AND login = ANY($3))
+	`
+	res, err := g.db.ExecContext(ctx, query, groupId, tenantId, logins)
+	if err != nil {
+		return 0, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupMembersRemove success | %d | %d | %v", tenantId, groupId, logins)
+
+	return res.RowsAffected()
+}
+
+func (g *Groups) GroupAssign(ctx context.Context, tenantId, groupId, variantId int, dueAt *time.Time) error {
+	g.logger.WithContext(ctx).InfoF("GroupAssign received | %d | %d | %d", tenantId, groupId, variantId)
+	ctx, done := operation(ctx, "groups", "GroupAssign")
+	defer done()
+
+	query := `
+		INSERT INTO group_assignments (group_id, variant_id, due_at)
+		SELECT g.id, v.id, $3
+		FROM groups g
+			JOIN variants v ON v.organization_id = g.organization_id
+		WHERE g.id = $1 AND v.id = $2 AND g.organization_id = $4
+		ON CONFLICT (group_id, variant_id) DO UPDATE SET due_at = EXCLUDED.due_at
+	`
+	if _, err := g.db.ExecContext(ctx, query, groupId, variantId, dueAt, tenantId); err != nil {
+		return err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupAssign success | %d | %d | %d", tenantId, groupId, variantId)
+
+	return nil
+}
+
+func (g *Groups) GroupProgress(ctx context.Context, tenantId, groupId int) ([]*entities.GroupProgress, error) {
+	g.logger.WithContext(ctx).InfoF("GroupProgress received | %d | %d", tenantId, groupId)
+	ctx, done := operation(ctx, "groups", "GroupProgress")
+	defer done()
+
+	var progress = make([]*entities.GroupProgress, 0)
+	query := `
+		SELECT
+			v.name AS variant_name,
+			ga.due_at,
+			a.login,
+			COALESCE(t.score, 0) AS score,
+			COALESCE(t.max_score, 0) AS max_score,
+			t.start_at,
+			t.finish_at
+		FROM group_assignments ga
+			JOIN variants v ON v.id = ga.variant_id
+			JOIN group_members gm ON gm.group_id = ga.group_id
+			JOIN auth a ON a.id = gm.user_id
+			LEFT JOIN testing t ON t.variant_id = ga.variant_id AND t.user_id = gm.user_id
+		WHERE ga.group_id = $1 AND v.organization_id = $2
+		ORDER BY v.name, a.login
+	`
+	if err := g.db.SelectContext(ctx, &progress, query, groupId, tenantId); err != nil {
+		return nil, err
+	}
+
+	g.logger.WithContext(ctx).InfoF("GroupProgress success | %d | %d", tenantId, groupId)
+
+	return progress, nil
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+)
+
+// Health answers the probes. Its methods run every few seconds, so unlike the
+// other repositories they do not log each call.
+type Health struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewHealth(db *sqlx.DB, logger logger.Logging) *Health {
+	return &Health{db: db, logger: logger}
+}
+
+func (h *Health) Ping(ctx context.Context) error {
+	return h.db.PingContext(ctx)
+}
+
+func (h *Health) SchemaVersion(ctx context.Context) (*entities.Schema, error) {
+	var schemaEntity = new(entities.Schema)
+
+	query := `
+		SELECT version, dirty FROM schema_migrations LIMIT 1
+	`
+	if err := h.db.GetContext(ctx, schemaEntity, query); err != nil {
+		return nil, err
+	}
+
+	return schemaEntity, nil
+}
+
+// SchemaExpected is the newest version in the migrations shipped with the
+// binary, the one InitPostgresConnection migrates to.
+func (h *Health) SchemaExpected() (uint, error) {
+	return latestMigration()
+}
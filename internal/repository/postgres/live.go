@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+type Live struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewLive(db *sqlx.DB, logger logger.Logging) *Live {
+	return &Live{db: db, logger: logger}
+}
+
+func (l *Live) LiveAdd(ctx context.Context, tenantId, variantId, hostId int, pin string) (*entities.LiveSession, error) {
+	l.logger.WithContext(ctx).InfoF("LiveAdd received | %d | %d | %d", tenantId, variantId, hostId)
+	ctx, done := operation(ctx, "live", "LiveAdd")
+	defer done()
+
+	var sessionEntity = new(entities.LiveSession)
+	query := `
+		INSERT INTO live_sessions (variant_id, host_id, pin)
+		SELECT id, $2, $3 FROM variants WHERE id = $1 AND organization_id = $4
+		RETURNING id, variant_id, host_id, pin, started_at, finished_at;
+	`
+	if err := l.db.GetContext(ctx, sessionEntity, query, variantId, hostId, pin, tenantId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorVariantNotFound
+		}
+		return nil, err
+	}
+
+	l.logger.WithContext(ctx).InfoF("LiveAdd success | %d | %d | %d", tenantId, variantId, hostId)
+
+	return sessionEntity, nil
+}
+
+func (l *Live) LiveFinish(ctx context.Context, tenantId, sessionId int, results []*entities.LiveScore) error {
+	l.logger.WithContext(ctx).InfoF("LiveFinish received | %d | %d", tenantId, sessionId)
+	ctx, done := operation(ctx, "live", "LiveFinish")
+	defer done()
+
+	tx, err := l.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	finishQuery := `
+		UPDATE live_sessions SET finished_at = $1
+		WHERE id = $2 AND variant_id IN (SELECT id FROM variants WHERE organization_id = $3)
+	`
+	res, err := tx.ExecContext(ctx, finishQuery, time.Now(), sessionId, tenantId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return constants.ErrorLiveNotFound
+	}
+
+	resultQuery := `
+		INSERT INTO live_results (session_id, user_id, rank, score, correct_answers) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (session_id, user_id) DO NOTHING
+	`
+	for _, result := range results {
+		if _, err := tx.ExecContext(ctx, resultQuery, sessionId, result.UserId, result.Rank, result.Score, result.CorrectAnswers); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	l.logger.WithContext(ctx).InfoF("LiveFinish success | %d | %d", tenantId, sessionId)
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"quiz-service/init/config"
+	"quiz-service/internal/entities"
+	"quiz-service/migrations"
+)
+
+// migrationsLockKey is the advisory lock held for as long as a Migrations is
+// open. migrate takes its own lock around every single operation; this one
+// covers the whole check-then-migrate sequence, so replicas starting together
+// migrate one after another and each sees the schema the previous one left.
+const migrationsLockKey = 5_102_349_117
+
+// ErrSchemaNewer means the database was migrated by a newer build: this binary
+// has no migration for that version and does not know the schema.
+var ErrSchemaNewer = errors.New("database schema is newer than this build supports")
+
+// Migrations drives the schema from the migrations embedded in the binary.
+// It holds the advisory lock from NewMigrations until Close.
+type Migrations struct {
+	m    *migrate.Migrate
+	db   *sql.DB
+	conn *sql.Conn
+}
+
+// NewMigrations connects with lib/pq, which migrate's postgres driver expects,
+// and without the pool's statement_timeout, since migrations may run long.
+// It waits for the lock as long as ctx allows.
+func NewMigrations(ctx context.Context, cfg *config.Config) (*Migrations, error) {
+	db, err := sql.Open("postgres", cfg.Postgres.DSN())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationsLockKey); err != nil {
+		conn.Close()
+		db.Close()
+		return nil, fmt.Errorf("waiting for the migrations lock: %w", err)
+	}
+
+	m, err := newMigrate(ctx, conn)
+	if err != nil {
+		// closing the session releases the lock
+		conn.Close()
+		db.Close()
+		return nil, err
+	}
+
+	return &Migrations{m: m, db: db, conn: conn}, nil
+}
+
+func newMigrate(ctx context.Context, conn *sql.Conn) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := migratepostgres.WithConnection(ctx, conn, &migratepostgres.Config{})
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+
+	return migrate.NewWithInstance("iofs", src, "postgres", driver)
+}
+
+// Up applies every pending migration, returning migrate.ErrNoChange when
+// there are none.
+func (m *Migrations) Up() error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	return m.m.Up()
+}
+
+// Down rolls back the given number of migrations, or all of them when steps
+// is zero.
+func (m *Migrations) Down(steps int) error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	if steps == 0 {
+		return m.m.Down()
+	}
+	return m.m.Steps(-steps)
+}
+
+// Force records version as applied and clean without running anything, to
+// recover from a migration that failed halfway and left the schema dirty.
+func (m *Migrations) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Status reports the applied version next to the newest one shipped with the
+// binary. A database that was never migrated is at version zero.
+func (m *Migrations) Status() (*entities.Schema, uint, error) {
+	latest, err := latestMigration()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return &entities.Schema{}, latest, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &entities.Schema{Version: version, Dirty: dirty}, latest, nil
+}
+
+// Check fails with ErrSchemaNewer when the database is past the newest
+// migration shipped with the binary.
+func (m *Migrations) Check() error {
+	schema, latest, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if schema.Version > latest {
+		return fmt.Errorf("%w: the database is at version %d, the newest migration here is %d", ErrSchemaNewer, schema.Version, latest)
+	}
+	return nil
+}
+
+func (m *Migrations) Close() error {
+	_, unlockErr := m.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationsLockKey)
+	// the driver closes the connection it was given, the source its files
+	sourceErr, databaseErr := m.m.Close()
+	return errors.Join(unlockErr, sourceErr, databaseErr, m.db.Close())
+}
+
+// latestMigration walks the embedded migrations to the newest version.
+func latestMigration() (uint, error) {
+	driver, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"quiz-service/internal/metrics"
+	"quiz-service/internal/tracing"
+	"time"
+)
+
+// operationTimeout bounds every repository method, so a slow query cannot hold
+// a request or a job past it. InitPostgresConnection sets it from the config;
+// zero leaves only the caller's deadline.
+var operationTimeout time.Duration
+
+// operation starts a repository method: it times it for metrics, opens its span
+// and applies operationTimeout. The returned func ends all three and is meant
+// to be deferred.
+func operation(ctx context.Context, repository, method string) (context.Context, func()) {
+	observe := metrics.ObserveQuery(repository, method)
+	ctx, span := tracing.Start(ctx, "postgres."+method)
+
+	cancel := func() {}
+	if operationTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, operationTimeout)
+	}
+
+	return ctx, func() {
+		cancel()
+		span.End()
+		observe()
+	}
+}
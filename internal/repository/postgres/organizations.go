@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+)
+
+type Organizations struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewOrganizations(db *sqlx.DB, logger logger.Logging) *Organizations {
+	return &Organizations{db: db, logger: logger}
+}
+
+func (o *Organizations) OrganizationAdd(ctx context.Context, name, inviteCode string) (*entities.Organization, error) {
+	o.logger.WithContext(ctx).InfoF("OrganizationAdd received | %s", name)
+	ctx, done := operation(ctx, "organizations", "OrganizationAdd")
+	defer done()
+
+	var organizationEntity = new(entities.Organization)
+	query := `
+		INSERT INTO organizations (name, invite_code) VALUES ($1, $2)
+		RETURNING id, name, invite_code, created_at;
+	`
+	if err := o.db.GetContext(ctx, organizationEntity, query, name, inviteCode); err != nil {
+		return nil, translate(err)
+	}
+
+	o.logger.WithContext(ctx).InfoF("OrganizationAdd success | %s", name)
+
+	return organizationEntity, nil
+}
+
+func (o *Organizations) OrganizationGet(ctx context.Context, name string) (*entities.Organization, error) {
+	o.logger.WithContext(ctx).InfoF("OrganizationGet received | %s", name)
+	ctx, done := operation(ctx, "organizations", "OrganizationGet")
+	defer done()
+
+	var organizationEntity = new(entities.Organization)
+	query := `
+		SELECT id, name, COALESCE(invite_code, '') AS invite_code, created_at FROM organizations WHERE name = $1
+	`
+	if err := o.db.GetContext(ctx, organizationEntity, query, name); err != nil {
+		return nil, err
+	}
+
+	o.logger.WithContext(ctx).InfoF("OrganizationGet success | %s", name)
+
+	return organizationEntity, nil
+}
+
+func (o *Organizations) OrganizationByInvite(ctx context.Context, inviteCode string) (*entities.Organization, error) {
+	o.logger.WithContext(ctx).Info("OrganizationByInvite received")
+	ctx, done := operation(ctx, "organizations", "OrganizationByInvite")
+	defer done()
+
+	var organizationEntity = new(entities.Organization)
+	query := `
+		SELECT id, name, invite_code, created_at FROM organizations WHERE invite_code = $1
+	`
+	if err := o.db.GetContext(ctx, organizationEntity, query, inviteCode); err != nil {
+		return nil, err
+	}
+
+	o.logger.WithContext(ctx).InfoF("OrganizationByInvite success | %s", organizationEntity.Name)
+
+	return organizationEntity, nil
+}
@@ -3,42 +3,134 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	"quiz-service/init/config"
 	"quiz-service/init/logger"
 )
 
-func InitPostgresConnection(ctx context.Context, cfg *config.Config, logger logger.Logging) (*sqlx.DB, error) {
-	uri := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Postgres.Username, cfg.Postgres.Password, cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.Database)
+const (
+	initialRetryDelay = 500 * time.Millisecond
+	maxRetryDelay     = 10 * time.Second
+)
 
-	db, err := sqlx.ConnectContext(ctx, "pgx", uri)
+// InitPostgresConnection is what the server starts with: a pool to the
+// database, and the schema migrated up to the version the binary ships with
+// unless db.migrate_on_start leaves that to the migrate command. Either way it
+// refuses a schema newer than the binary knows.
+func InitPostgresConnection(ctx context.Context, cfg *config.Config, logger logger.Logging) (*sqlx.DB, error) {
+	db, err := Connect(ctx, cfg, logger)
 	if err != nil {
-		logger.Error(err.Error())
 		return nil, err
 	}
 
-	m, err := migrate.New("file://./migrations", uri)
-	if err != nil {
+	if err := startupMigrations(ctx, cfg, logger); err != nil {
+		db.Close()
 		logger.Error(err.Error())
 		return nil, err
 	}
 
+	return db, nil
+}
+
+func startupMigrations(ctx context.Context, cfg *config.Config, logger logger.Logging) (err error) {
+	m, err := NewMigrations(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, m.Close())
+	}()
+
+	if !cfg.Postgres.MigrateOnStart {
+		if err := m.Check(); err != nil {
+			return err
+		}
+		// an older schema is left to the operator; /readyz stays 503 until then
+		schema, latest, err := m.Status()
+		if err != nil {
+			return err
+		}
+		if schema.Dirty || schema.Version < latest {
+			logger.ErrorF("schema is at version %d (dirty: %t), %d is expected: run migrate up", schema.Version, schema.Dirty, latest)
+		}
+		return nil
+	}
+
 	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			logger.Debug("migrations already up to date")
-		} else {
-			logger.Error(err.Error())
-			return nil, err
+		if !errors.Is(err, migrate.ErrNoChange) {
+			return err
 		}
+		logger.Debug("migrations already up to date")
+	}
+
+	return nil
+}
+
+// Connect opens the pool without touching the schema, for commands that must
+// not migrate implicitly.
+func Connect(ctx context.Context, cfg *config.Config, logger logger.Logging) (*sqlx.DB, error) {
+	// statement_timeout goes to the server as a session parameter of the pool's
+	// connections only; migrations may legitimately run longer.
+	uri := cfg.Postgres.DSN()
+	if cfg.Postgres.StatementTimeout > 0 {
+		uri += "&statement_timeout=" + strconv.FormatInt(cfg.Postgres.StatementTimeout.Milliseconds(), 10)
+	}
+	operationTimeout = cfg.Postgres.OperationTimeout
+
+	// otelsql opens a span per statement with the SQL text as db.statement,
+	// nested under the span of the repository method that runs it.
+	sqlDB, err := otelsql.Open("pgx", uri,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitRows: true, OmitConnResetSession: true}))
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	db := sqlx.NewDb(sqlDB, "pgx")
+	db.SetMaxOpenConns(cfg.Postgres.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Postgres.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Postgres.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.Postgres.ConnMaxIdleTime)
+	if err := ping(ctx, db, cfg.Postgres.ConnectMaxWait, logger); err != nil {
+		db.Close()
+		logger.Error(err.Error())
+		return nil, err
 	}
 
 	return db, nil
 }
+
+// ping waits for Postgres to accept connections, which under docker-compose can
+// be a while after its container reports started. It retries with exponential
+// backoff and gives up with the last error once maxWait has passed.
+func ping(ctx context.Context, db *sqlx.DB, maxWait time.Duration, logger logger.Logging) error {
+	deadline := time.Now().Add(maxWait)
+	delay := initialRetryDelay
+
+	for {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		logger.ErrorF("postgres is not reachable, retrying in %s: %v", delay, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
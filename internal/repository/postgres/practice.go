@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+type Practice struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewPractice(db *sqlx.DB, logger logger.Logging) *Practice {
+	return &Practice{db: db, logger: logger}
+}
+
+func (p *Practice) PracticeStart(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error) {
+	p.logger.WithContext(ctx).InfoF("PracticeStart received | %d | %d | %d", tenantId, variantId, userId)
+	ctx, done := operation(ctx, "practice", "PracticeStart")
+	defer done()
+
+	var practiceEntity = new(entities.Practice)
+	query := `
+		INSERT INTO practice_testing (user_id, variant_id)
+		SELECT $1, id FROM variants WHERE id = $2 AND organization_id = $3
+		RETURNING id, user_id, variant_id, correct_answers, attempts, start_at, finish_at;
+	`
+	if err := p.db.GetContext(ctx, practiceEntity, query, userId, variantId, tenantId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorVariantNotFound
+		}
+		return nil, err
+	}
+
+	p.logger.WithContext(ctx).InfoF("PracticeStart success | %d | %d | %d", tenantId, variantId, userId)
+
+	return practiceEntity, nil
+}
+
+func (p *Practice) PracticeGet(ctx context.Context, tenantId, userId, variantId int) (*entities.Practice, error) {
+	p.logger.WithContext(ctx).InfoF("PracticeGet received | %d | %d | %d", tenantId, userId, variantId)
+	ctx, done := operation(ctx, "practice", "PracticeGet")
+	defer done()
+
+	var practiceEntity = new(entities.Practice)
+	query := `
+		SELECT pt.id, pt.user_id, pt.variant_id, pt.correct_answers, pt.attempts, pt.start_at, pt.finish_at
+		FROM practice_testing pt
+			JOIN variants v ON v.id = pt.variant_id
+		WHERE pt.user_id = $1 AND pt.variant_id = $2 AND v.organization_id = $3 AND pt.finish_at IS NULL
+		ORDER BY pt.id DESC
+		LIMIT 1
+	`
+	if err := p.db.GetContext(ctx, practiceEntity, query, userId, variantId, tenantId); err != nil {
+		return nil, err
+	}
+
+	p.logger.WithContext(ctx).InfoF("PracticeGet success | %d | %d | %d", tenantId, userId, variantId)
+
+	return practiceEntity, nil
+}
+
+// PracticeAccept records the selected options, one row each, with the verdict
+// the service reached for them, and counts the attempt.
+func (p *Practice) PracticeAccept(ctx context.Context, tenantId, practiceId, questionId int, answers []string, correct bool) error {
+	p.logger.WithContext(ctx).InfoF("PracticeAccept received | %d | %d | %d | %v | %t", tenantId, practiceId, questionId, answers, correct)
+	ctx, done := operation(ctx, "practice", "PracticeAccept")
+	defer done()
+
+	tx, err := p.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `
+		UPDATE practice_testing SET attempts = attempts + 1
+		WHERE id = $1 AND variant_id IN (SELECT id FROM variants WHERE organization_id = $2)
+	`
+	result, err := tx.ExecContext(ctx, updateQuery, practiceId, tenantId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if num, err := result.RowsAffected(); err != nil || num == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return constants.ErrorPracticeNotFound
+	}
+
+	insertQuery := `
+		INSERT INTO practice_answers (practice_id, question_id, answer, correct) VALUES ($1, $2, $3, $4)
+	`
+	for _, answer := range answers {
+		if _, err := tx.ExecContext(ctx, insertQuery, practiceId, questionId, answer, correct); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	p.logger.WithContext(ctx).InfoF("PracticeAccept success | %d | %d | %d | %v | %t", tenantId, practiceId, questionId, answers, correct)
+
+	return nil
+}
+
+func (p *Practice) PracticeFinish(ctx context.Context, tenantId, practiceId int) (*entities.Practice, error) {
+	p.logger.WithContext(ctx).InfoF("PracticeFinish received | %d | %d", tenantId, practiceId)
+	ctx, done := operation(ctx, "practice", "PracticeFinish")
+	defer done()
+
+	var practiceEntity = new(entities.Practice)
+	query := `
+		UPDATE practice_testing
+		SET finish_at = $1,
+			correct_answers = (
+				SELECT COUNT(DISTINCT question_id)
+				FROM practice_answers
+				WHERE practice_id = $2 AND correct
+			)
+		WHERE id = $2 AND variant_id IN (SELECT id FROM variants WHERE organization_id = $3)
+		RETURNING id, user_id, variant_id, correct_answers, attempts, start_at, finish_at;
+	`
+	if err := p.db.GetContext(ctx, practiceEntity, query, time.Now(), practiceId, tenantId); err != nil {
+		return nil, err
+	}
+
+	p.logger.WithContext(ctx).InfoF("PracticeFinish success | %d | %d", tenantId, practiceId)
+
+	return practiceEntity, nil
+}
+
+// PracticeResults reads the latest practice, finished or not, without changing
+// it. An unfinished one counts its correct answers so far.
+func (p *Practice) PracticeResults(ctx context.Context, tenantId, userId, variantId int) (*entities.Practice, error) {
+	p.logger.WithContext(ctx).InfoF("PracticeResults received | %d | %d | %d", tenantId, userId, variantId)
+	ctx, done := operation(ctx, "practice", "PracticeResults")
+	defer done()
+
+	var practiceEntity = new(entities.Practice)
+	query := `
+		SELECT
+			pt.id, pt.user_id, pt.variant_id, pt.attempts, pt.start_at, pt.finish_at,
+			CASE WHEN pt.finish_at IS NULL THEN (
+				SELECT COUNT(DISTINCT question_id)
+				FROM practice_answers
+				WHERE practice_id = pt.id AND correct
+			) ELSE pt.correct_answers END AS correct_answers
+		FROM practice_testing pt
+			JOIN variants v ON v.id = pt.variant_id
+		WHERE pt.user_id = $1 AND pt.variant_id = $2 AND v.organization_id = $3
+		ORDER BY pt.id DESC
+		LIMIT 1
+	`
+	if err := p.db.GetContext(ctx, practiceEntity, query, userId, variantId, tenantId); err != nil {
+		return nil, err
+	}
+
+	p.logger.WithContext(ctx).InfoF("PracticeResults success | %d | %d | %d", tenantId, userId, variantId)
+
+	return practiceEntity, nil
+}
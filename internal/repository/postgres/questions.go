@@ -4,41 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"github.com/jmoiron/sqlx"
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
-	"sync"
+	"quiz-service/pkg/constants"
 )
 
 type Questions struct {
 	db     *sqlx.DB
 	logger logger.Logging
-
-	mu sync.Mutex
 }
 
 func NewQuestions(db *sqlx.DB, logger logger.Logging) *Questions {
-	return &Questions{db: db, mu: sync.Mutex{}, logger: logger}
+	return &Questions{db: db, logger: logger}
 }
 
-func (q *Questions) QuestionCount(ctx context.Context, variantId int) (int, error) {
-	q.logger.InfoF("QuestionCount received | %d", variantId)
+func (q *Questions) QuestionCount(ctx context.Context, tenantId, variantId int) (int, error) {
+	q.logger.WithContext(ctx).InfoF("QuestionCount received | %d | %d", tenantId, variantId)
+	ctx, done := operation(ctx, "questions", "QuestionCount")
+	defer done()
 
 	var count int
 	query := `
-		SELECT COUNT(*) FROM questions WHERE variant_id = $1
+		SELECT COUNT(*)
+		FROM questions q
+			JOIN variants v ON v.id = q.variant_id
+		WHERE q.variant_id = $1 AND v.organization_id = $2
 	`
-	if err := q.db.GetContext(ctx, &count, query, variantId); err != nil {
+	if err := q.db.GetContext(ctx, &count, query, variantId, tenantId); err != nil {
 		return 0, err
 	}
 
-	q.logger.InfoF("QuestionCount success | %d", variantId)
+	q.logger.WithContext(ctx).InfoF("QuestionCount success | %d | %d", tenantId, variantId)
 
 	return count, nil
 }
 
-func (q *Questions) QuestionAdd(ctx context.Context, variantId int, question *entities.Question) error {
-	q.logger.InfoF("QuestionAdd received %d | %+v", variantId, question)
+func (q *Questions) QuestionAdd(ctx context.Context, tenantId, variantId int, question *entities.Question) error {
+	q.logger.WithContext(ctx).InfoF("QuestionAdd received %d | %d | %+v", tenantId, variantId, question)
+	ctx, done := operation(ctx, "questions", "QuestionAdd")
+	defer done()
 
 	tx, err := q.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
@@ -47,20 +53,26 @@ func (q *Questions) QuestionAdd(ctx context.Context, variantId int, question *en
 
 	var questionId int
 	questionQuery := `
-		INSERT INTO questions (variant_id, question, answer) VALUES ($1, $2, $3) RETURNING id;
+		INSERT INTO questions (variant_id, question, answer, explanation, points, negative_points, multiple)
+		SELECT id, $2, $3, $4, $5, $6, $7 FROM variants WHERE id = $1 AND organization_id = $8
+		RETURNING id;
 	`
-	if err := tx.GetContext(ctx, &questionId, questionQuery, variantId, question.Question, question.Answer); err != nil {
+	if err := tx.GetContext(ctx, &questionId, questionQuery, variantId, question.Question, question.Answer,
+		question.Explanation, question.Points, question.NegativePoints, question.Multiple, tenantId); err != nil {
 		tx.Rollback()
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return constants.ErrorVariantNotFound
+		}
+		return translate(err)
 	}
 
 	var answerIds = make([]int, 0, len(question.Answers))
 	for _, answer := range question.Answers {
 		var answerId int
 		answerQuery := `
-			INSERT INTO answers (answer) VALUES ($1) RETURNING id;
+			INSERT INTO answers (answer, feedback, correct) VALUES ($1, $2, $3) RETURNING id;
 		`
-		if err := tx.GetContext(ctx, &answerId, answerQuery, answer.Answer); err != nil {
+		if err := tx.GetContext(ctx, &answerId, answerQuery, answer.Answer, answer.Feedback, answer.Correct); err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -77,13 +89,25 @@ func (q *Questions) QuestionAdd(ctx context.Context, variantId int, question *en
 		}
 	}
 
-	q.logger.InfoF("QuestionAdd success | %d | %+v", variantId, question)
+	for _, hint := range question.Hints {
+		hintQuery := `
+			INSERT INTO hints (question_id, hint, penalty) VALUES ($1, $2, $3)
+		`
+		if _, err := tx.ExecContext(ctx, hintQuery, questionId, hint.Hint, hint.Penalty); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	q.logger.WithContext(ctx).InfoF("QuestionAdd success | %d | %d | %+v", tenantId, variantId, question)
 
 	return tx.Commit()
 }
 
-func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question string) (int64, error) {
-	q.logger.InfoF("QuestionRemove received %d | %s", variantId, question)
+func (q *Questions) QuestionRemove(ctx context.Context, tenantId, variantId int, question string) (int64, error) {
+	q.logger.WithContext(ctx).InfoF("QuestionRemove received %d | %d | %s", tenantId, variantId, question)
+	ctx, done := operation(ctx, "questions", "QuestionRemove")
+	defer done()
 
 	tx, err := q.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
@@ -92,9 +116,14 @@ func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question
 
 	queryDeleteLinks := `
 		DELETE FROM questions_and_answers
-		WHERE questions_id = (SELECT id FROM questions WHERE variant_id = $1 AND question = $2)
+		WHERE questions_id = (
+			SELECT q.id
+			FROM questions q
+				JOIN variants v ON v.id = q.variant_id
+			WHERE q.variant_id = $1 AND q.question = $2 AND v.organization_id = $3
+		)
 	`
-	_, err = tx.ExecContext(ctx, queryDeleteLinks, variantId, question)
+	_, err = tx.ExecContext(ctx, queryDeleteLinks, variantId, question, tenantId)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
@@ -111,9 +140,11 @@ func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question
 	}
 
 	queryDeleteQuestion := `
-		DELETE FROM questions WHERE variant_id = $1 AND question = $2
+		DELETE FROM questions
+		WHERE variant_id = $1 AND question = $2
+			AND variant_id IN (SELECT id FROM variants WHERE organization_id = $3)
 	`
-	res, err := tx.ExecContext(ctx, queryDeleteQuestion, variantId, question)
+	res, err := tx.ExecContext(ctx, queryDeleteQuestion, variantId, question, tenantId)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
@@ -123,13 +154,15 @@ func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question
 		return 0, err
 	}
 
-	q.logger.InfoF("QuestionRemove success %d | %s", variantId, question)
+	q.logger.WithContext(ctx).InfoF("QuestionRemove success %d | %d | %s", tenantId, variantId, question)
 
 	return res.RowsAffected()
 }
 
-func (q *Questions) QuestionGet(ctx context.Context, variantId, questionId int) (*entities.Question, error) {
-	q.logger.InfoF("QuestionGet received %d | %d", variantId, questionId)
+func (q *Questions) QuestionGet(ctx context.Context, tenantId, variantId, questionId int) (*entities.Question, error) {
+	q.logger.WithContext(ctx).InfoF("QuestionGet received %d | %d | %d", tenantId, variantId, questionId)
+	ctx, done := operation(ctx, "questions", "QuestionGet")
+	defer done()
 
 	var question = new(entities.Question)
 	var answers = new([]byte)
@@ -139,15 +172,20 @@ func (q *Questions) QuestionGet(ctx context.Context, variantId, questionId int)
 			q.id,
 			q.question,
 			q.answer,
-			json_agg(json_build_object('answer', ans.answer)) AS answers
+			q.explanation,
+			q.points,
+			q.negative_points,
+			q.multiple,
+			json_agg(json_build_object('answer', ans.answer, 'feedback', ans.feedback, 'correct', ans.correct)) AS answers
 		FROM questions q
 			JOIN variants v ON v.id = q.variant_id
 			JOIN questions_and_answers qa ON qa.questions_id = q.id
 			JOIN answers ans ON ans.id = qa.answers_id
-		WHERE q.id = $1 AND q.variant_id = $2
-		GROUP BY q.question, v.name, q.answer, q.id
+		WHERE q.id = $1 AND q.variant_id = $2 AND v.organization_id = $3
+		GROUP BY q.question, v.name, q.answer, q.explanation, q.points, q.negative_points, q.multiple, q.id
 	`
-	if err := q.db.QueryRowxContext(ctx, query, questionId, variantId).Scan(&question.Id, &question.Question, &question.Answer, answers); err != nil {
+	if err := q.db.QueryRowxContext(ctx, query, questionId, variantId, tenantId).Scan(&question.Id, &question.Question, &question.Answer,
+		&question.Explanation, &question.Points, &question.NegativePoints, &question.Multiple, answers); err != nil {
 		return nil, err
 	}
 
@@ -155,49 +193,149 @@ func (q *Questions) QuestionGet(ctx context.Context, variantId, questionId int)
 		return nil, err
 	}
 
-	q.logger.InfoF("QuestionGet success %d | %d", variantId, questionId)
+	hintsQuery := `
+		SELECT id, hint, penalty FROM hints WHERE question_id = $1 ORDER BY id
+	`
+	if err := q.db.SelectContext(ctx, &question.Hints, hintsQuery, questionId); err != nil {
+		return nil, err
+	}
+
+	q.logger.WithContext(ctx).InfoF("QuestionGet success %d | %d | %d", tenantId, variantId, questionId)
 
 	return question, nil
 }
 
-func (q *Questions) QuestionAccept(ctx context.Context, answer string, userId, testId, variantId int) error {
-	q.logger.InfoF("QuestionGet received %s | %d | %d | %d", variantId, userId, testId, answer)
+func (q *Questions) QuestionAccept(ctx context.Context, tenantId, testId, questionId int, answers []string, points float64, correct bool) error {
+	q.logger.WithContext(ctx).InfoF("QuestionAccept received %d | %d | %d | %v | %.2f", tenantId, testId, questionId, answers, points)
+	ctx, done := operation(ctx, "questions", "QuestionAccept")
+	defer done()
 
-	var questionEntity = new(entities.Question)
-	query := `
-		SELECT id, answer, question
-		FROM questions 
-		WHERE variant_id = $1
+	tx, err := q.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	var id int
+	testQuery := `
+		SELECT t.id
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE t.id = $1 AND v.organization_id = $2
 	`
-	if err := q.db.GetContext(ctx, questionEntity, query, variantId); err != nil {
+	if err := tx.GetContext(ctx, &id, testQuery, testId, tenantId); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return constants.ErrorTestNotFound
+		}
 		return err
 	}
 
+	scoreQuery := `
+		INSERT INTO user_answer_scores (test_id, question_id, points) VALUES ($1, $2, $3)
+		ON CONFLICT (test_id, question_id) DO NOTHING
+	`
+	res, err := tx.ExecContext(ctx, scoreQuery, testId, questionId, points)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return constants.ErrorQuestionAnswered
+	}
+
 	insertQuery := `
-			INSERT INTO user_answers (test_id, question_id, answer) VALUES ($1, $2, $3)
+		INSERT INTO user_answers (test_id, question_id, answer) VALUES ($1, $2, $3)
 	`
-	if questionEntity.Answer == answer {
-		q.mu.Lock()
-		if _, err := q.db.ExecContext(ctx, insertQuery, testId, questionEntity.Id, answer); err != nil {
+	for _, answer := range answers {
+		if _, err := tx.ExecContext(ctx, insertQuery, testId, questionId, answer); err != nil {
+			tx.Rollback()
 			return err
 		}
+	}
 
+	if correct {
 		updateQuery := `
 			UPDATE testing SET correct_answers = correct_answers + 1
-			WHERE user_id = $1 AND variant_id = $2
+			WHERE id = $1
 		`
-		if _, err := q.db.ExecContext(ctx, updateQuery, userId, variantId); err != nil {
+		if _, err := tx.ExecContext(ctx, updateQuery, testId); err != nil {
+			tx.Rollback()
 			return err
 		}
-
-		q.mu.Unlock()
 	}
 
-	if _, err := q.db.ExecContext(ctx, insertQuery, testId, questionEntity.Id, answer); err != nil {
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
-	q.logger.InfoF("QuestionGet success %s | %d | %d | %d", variantId, userId, testId, answer)
+	q.logger.WithContext(ctx).InfoF("QuestionAccept success %d | %d | %d | %v | %.2f", tenantId, testId, questionId, answers, points)
 
 	return nil
 }
+
+func (q *Questions) QuestionHint(ctx context.Context, tenantId, testId, variantId, questionId int) (*entities.Hint, error) {
+	q.logger.WithContext(ctx).InfoF("QuestionHint received %d | %d | %d | %d", tenantId, testId, variantId, questionId)
+	ctx, done := operation(ctx, "questions", "QuestionHint")
+	defer done()
+
+	tx, err := q.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	questionQuery := `
+		SELECT q.id
+		FROM questions q
+			JOIN variants v ON v.id = q.variant_id
+		WHERE q.id = $1 AND q.variant_id = $2 AND v.organization_id = $3
+	`
+	if err := tx.GetContext(ctx, &id, questionQuery, questionId, variantId, tenantId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var hintEntity = new(entities.Hint)
+	hintQuery := `
+		SELECT id, hint, penalty
+		FROM hints
+		WHERE question_id = $1 AND id NOT IN (SELECT hint_id FROM revealed_hints WHERE test_id = $2)
+		ORDER BY id
+		LIMIT 1
+	`
+	if err := tx.GetContext(ctx, hintEntity, hintQuery, questionId, testId); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorNoHintsLeft
+		}
+		return nil, err
+	}
+
+	revealQuery := `
+		INSERT INTO revealed_hints (test_id, hint_id) VALUES ($1, $2)
+	`
+	if _, err := tx.ExecContext(ctx, revealQuery, testId, hintEntity.Id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	penaltyQuery := `
+		UPDATE testing SET penalty = penalty + $1 WHERE id = $2
+	`
+	if _, err := tx.ExecContext(ctx, penaltyQuery, hintEntity.Penalty, testId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	q.logger.WithContext(ctx).InfoF("QuestionHint success %d | %d | %d | %d", tenantId, testId, variantId, questionId)
+
+	return hintEntity, nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"quiz-service/init/logger"
 	"time"
 
@@ -20,40 +21,63 @@ func NewRegister(db *sqlx.DB, logger logger.Logging) *Register {
 }
 
 func (r Register) Register(ctx context.Context, register *entities.Register) (*entities.User, error) {
-	r.logger.InfoF("Register received | %+v", register)
+	r.logger.WithContext(ctx).InfoF("Register received | %d | %s", register.OrganizationId, register.Login)
+	ctx, done := operation(ctx, "register", "Register")
+	defer done()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, err
+	}
 
 	var userEntity = new(entities.User)
 
 	query := `
-		INSERT INTO auth (uuid, login, password, authorized) 
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, uuid, login, authorized, authorized_at, quit_at
+		INSERT INTO auth (uuid, login, password, authorized, organization_id) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, organization_id, uuid, login, authorized, authorized_at, quit_at, role
 	`
-	if err := r.db.GetContext(ctx, userEntity, query, register.UUID, register.Login, register.Password, true); err != nil {
+	if err := tx.GetContext(ctx, userEntity, query, register.UUID, register.Login, register.Password, true, register.OrganizationId); err != nil {
+		tx.Rollback()
+		return nil, translate(err)
+	}
+
+	outboxQuery := `
+		INSERT INTO webhook_outbox (organization_id, event, payload)
+		VALUES ($1, $2, json_build_object('user_id', $3::integer, 'login', $4::text, 'registered_at', now()))
+	`
+	if _, err := tx.ExecContext(ctx, outboxQuery, userEntity.OrganizationId, entities.WebhookUserRegistered, userEntity.ID, userEntity.Login); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	r.logger.InfoF("Register success | %+v", register)
+	r.logger.WithContext(ctx).InfoF("Register success | %d | %s", userEntity.OrganizationId, userEntity.Login)
 
 	return userEntity, nil
 }
 
 func (r Register) Login(ctx context.Context, login *entities.Login) (*entities.User, error) {
-	r.logger.InfoF("Login received | %+v", login)
+	r.logger.WithContext(ctx).InfoF("Login received | %s", login.Login)
+	ctx, done := operation(ctx, "register", "Login")
+	defer done()
 
 	var userEntity = new(entities.User)
 
 	query := `
 		UPDATE auth 
 		SET authorized = true, authorized_at = $1
-		WHERE login = $2 AND password = $3
-		RETURNING uuid, login, authorized, authorized_at, quit_at
+		WHERE login = $2 AND password = $3 AND disabled_at IS NULL
+		RETURNING uuid, organization_id, login, authorized, authorized_at, quit_at, role
 	`
 	if err := r.db.GetContext(ctx, userEntity, query, time.Now(), login.Login, login.Password); err != nil {
 		return nil, err
 	}
 
-	r.logger.InfoF("Login success | %+v", login)
+	r.logger.WithContext(ctx).InfoF("Login success | %s", login.Login)
 
 	return userEntity, nil
 }
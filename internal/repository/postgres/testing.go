@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"fmt"
 	"github.com/jmoiron/sqlx"
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
@@ -17,21 +16,84 @@ func NewTesting(db *sqlx.DB, logger logger.Logging) *Testing {
 	return &Testing{db: db, logger: logger}
 }
 
-func (t *Testing) TestGet(ctx context.Context, userId, variantId int) (*entities.Testing, error) {
-	t.logger.InfoF("TestGet received | %d | %d", userId, variantId)
+func (t *Testing) TestGet(ctx context.Context, tenantId, userId, variantId int) (*entities.Testing, error) {
+	t.logger.WithContext(ctx).InfoF("TestGet received | %d | %d | %d", tenantId, userId, variantId)
+	ctx, done := operation(ctx, "testing", "TestGet")
+	defer done()
 
 	var testEntity = new(entities.Testing)
 	query := `
-		SELECT id, user_id, variant_id, correct_answers, start_at, finish_at 
-		FROM testing 
-		WHERE user_id = $1 AND variant_id = $2
+		SELECT t.id, t.user_id, t.variant_id, t.correct_answers, t.penalty, t.score, t.max_score, t.start_at, t.finish_at 
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE t.user_id = $1 AND t.variant_id = $2 AND v.organization_id = $3
 	`
-	if err := t.db.GetContext(ctx, testEntity, query, userId, variantId); err != nil {
-		fmt.Println(err.Error())
+	if err := t.db.GetContext(ctx, testEntity, query, userId, variantId, tenantId); err != nil {
 		return nil, err
 	}
 
-	t.logger.InfoF("TestGet success | %d | %d", userId, variantId)
+	t.logger.WithContext(ctx).InfoF("TestGet success | %d | %d | %d", tenantId, userId, variantId)
 
 	return testEntity, nil
 }
+
+func (t *Testing) TestReview(ctx context.Context, tenantId, testId int) ([]*entities.Review, error) {
+	t.logger.WithContext(ctx).InfoF("TestReview received | %d | %d", tenantId, testId)
+	ctx, done := operation(ctx, "testing", "TestReview")
+	defer done()
+
+	var reviews = make([]*entities.Review, 0)
+	query := `
+		SELECT
+			q.id AS question_id,
+			q.question,
+			ua.answer,
+			ua.answer = q.answer OR COALESCE(f.correct, false) AS correct,
+			COALESCE(s.points, 0) AS points,
+			q.answer AS correct_answer,
+			q.explanation,
+			COALESCE(f.feedback, '') AS feedback
+		FROM user_answers ua
+			JOIN questions q ON q.id = ua.question_id
+			JOIN variants v ON v.id = q.variant_id
+			LEFT JOIN LATERAL (
+				SELECT a.feedback, a.correct
+				FROM questions_and_answers qa
+					JOIN answers a ON a.id = qa.answers_id
+				WHERE qa.questions_id = q.id AND a.answer = ua.answer
+				LIMIT 1
+			) f ON true
+			LEFT JOIN user_answer_scores s ON s.test_id = ua.test_id AND s.question_id = ua.question_id
+		WHERE ua.test_id = $1 AND v.organization_id = $2
+		ORDER BY ua.id
+	`
+	if err := t.db.SelectContext(ctx, &reviews, query, testId, tenantId); err != nil {
+		return nil, err
+	}
+
+	t.logger.WithContext(ctx).InfoF("TestReview success | %d | %d", tenantId, testId)
+
+	return reviews, nil
+}
+
+func (t *Testing) TestUnfinished(ctx context.Context, tenantId, variantId int) ([]*entities.Testing, error) {
+	t.logger.WithContext(ctx).InfoF("TestUnfinished received | %d | %d", tenantId, variantId)
+	ctx, done := operation(ctx, "testing", "TestUnfinished")
+	defer done()
+
+	var tests = make([]*entities.Testing, 0)
+	query := `
+		SELECT t.id, t.user_id, a.login, t.variant_id, t.correct_answers, t.penalty, t.score, t.max_score, t.start_at, t.finish_at
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+			JOIN auth a ON a.id = t.user_id
+		WHERE t.variant_id = $1 AND v.organization_id = $2 AND t.finish_at IS NULL
+	`
+	if err := t.db.SelectContext(ctx, &tests, query, variantId, tenantId); err != nil {
+		return nil, err
+	}
+
+	t.logger.WithContext(ctx).InfoF("TestUnfinished success | %d | %d", tenantId, variantId)
+
+	return tests, nil
+}
@@ -19,12 +19,16 @@ func NewUser(db *sqlx.DB, logger logger.Logging) *User {
 }
 
 func (u *User) Authenticated(ctx context.Context, uuid string) (*entities.User, error) {
-	u.logger.InfoF("Authenticated received | %s", uuid)
+	u.logger.WithContext(ctx).Info("Authenticated received")
+	ctx, done := operation(ctx, "user", "Authenticated")
+	defer done()
 
 	var userEntity = new(entities.User)
 
 	query := `
-		SELECT id, uuid, login, authorized, authorized_at, quit_at FROM auth WHERE uuid = $1
+		SELECT id, organization_id, uuid, login, authorized, authorized_at, quit_at, role
+		FROM auth
+		WHERE uuid = $1 AND disabled_at IS NULL
 	`
 	if err := u.db.GetContext(ctx, userEntity, query, uuid); err != nil {
 		return nil, err
@@ -34,13 +38,15 @@ func (u *User) Authenticated(ctx context.Context, uuid string) (*entities.User,
 		return nil, constants.ErrorUserNotAuthorized
 	}
 
-	u.logger.InfoF("Authenticated success | %s", uuid)
+	u.logger.WithContext(ctx).InfoF("Authenticated success | %d", userEntity.ID)
 
 	return userEntity, nil
 }
 
 func (u *User) Quit(ctx context.Context, uuid string) (int64, error) {
-	u.logger.InfoF("Quit received | %s", uuid)
+	u.logger.WithContext(ctx).Info("Quit received")
+	ctx, done := operation(ctx, "user", "Quit")
+	defer done()
 
 	now := time.Now()
 
@@ -54,7 +60,68 @@ func (u *User) Quit(ctx context.Context, uuid string) (int64, error) {
 		return 0, err
 	}
 
-	u.logger.InfoF("Quit success | %s", uuid)
+	u.logger.WithContext(ctx).Info("Quit success")
+
+	return res.RowsAffected()
+}
+
+func (u *User) UserPasswordSet(ctx context.Context, login, password string) (int64, error) {
+	u.logger.WithContext(ctx).InfoF("UserPasswordSet received | %s", login)
+	ctx, done := operation(ctx, "user", "UserPasswordSet")
+	defer done()
+
+	// the old sessions were opened with the old password, so they end here too
+	query := `
+		UPDATE auth
+		SET password = $2, authorized = false, quit_at = $3
+		WHERE login = $1;
+	`
+	res, err := u.db.ExecContext(ctx, query, login, password, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	u.logger.WithContext(ctx).InfoF("UserPasswordSet success | %s", login)
+
+	return res.RowsAffected()
+}
+
+func (u *User) UserRoleSet(ctx context.Context, login, role string) (int64, error) {
+	u.logger.WithContext(ctx).InfoF("UserRoleSet received | %s | %s", login, role)
+	ctx, done := operation(ctx, "user", "UserRoleSet")
+	defer done()
+
+	query := `
+		UPDATE auth SET role = $2 WHERE login = $1;
+	`
+	res, err := u.db.ExecContext(ctx, query, login, role)
+	if err != nil {
+		return 0, err
+	}
+
+	u.logger.WithContext(ctx).InfoF("UserRoleSet success | %s | %s", login, role)
+
+	return res.RowsAffected()
+}
+
+func (u *User) UserDisable(ctx context.Context, login string) (int64, error) {
+	u.logger.WithContext(ctx).InfoF("UserDisable received | %s", login)
+	ctx, done := operation(ctx, "user", "UserDisable")
+	defer done()
+
+	now := time.Now()
+
+	query := `
+		UPDATE auth
+		SET disabled_at = COALESCE(disabled_at, $2), authorized = false, quit_at = $2
+		WHERE login = $1;
+	`
+	res, err := u.db.ExecContext(ctx, query, login, now)
+	if err != nil {
+		return 0, err
+	}
+
+	u.logger.WithContext(ctx).InfoF("UserDisable success | %s", login)
 
 	return res.RowsAffected()
 }
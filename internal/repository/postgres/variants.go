@@ -21,23 +21,78 @@ func NewVariant(db *sqlx.DB, logger logger.Logging) *Variant {
 	return &Variant{db: db, logger: logger}
 }
 
-func (v *Variant) VariantAdd(ctx context.Context, name string) error {
-	v.logger.InfoF("VariantAdd received | %s", name)
+func (v *Variant) VariantAdd(ctx context.Context, tenantId int, variant *entities.Variant) error {
+	v.logger.WithContext(ctx).InfoF("VariantAdd received | %d | %+v", tenantId, variant)
+	ctx, done := operation(ctx, "variants", "VariantAdd")
+	defer done()
 
+	tx, err := v.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	var variantId int
 	query := `
-		INSERT INTO variants (name) VALUES ($1);
+		INSERT INTO variants (organization_id, name, reveal_answers, reveal_explanations, pass_mark, opens_at, closes_at, restricted, practice, author_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
 	`
-	if _, err := v.db.ExecContext(ctx, query, name); err != nil {
+	if err := tx.GetContext(ctx, &variantId, query, tenantId, variant.Name, variant.RevealAnswers, variant.RevealExplanations,
+		variant.PassMark, variant.OpensAt, variant.ClosesAt, variant.Restricted, variant.Practice, variant.AuthorId); err != nil {
+		tx.Rollback()
+		if hasCode(err, stringDataRightTruncated) {
+			return constants.ErrorVariantTooLong
+		}
+		return translate(err)
+	}
+
+	outboxQuery := `
+		INSERT INTO webhook_outbox (organization_id, event, payload)
+		SELECT organization_id, $2, json_build_object(
+			'variant_id', id, 'name', name, 'pass_mark', pass_mark,
+			'opens_at', opens_at, 'closes_at', closes_at, 'restricted', restricted
+		)
+		FROM variants WHERE id = $1;
+	`
+	if _, err := tx.ExecContext(ctx, outboxQuery, variantId, entities.WebhookVariantPublished); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
-	v.logger.InfoF("VariantAdd success | %s", name)
+	v.logger.WithContext(ctx).InfoF("VariantAdd success | %d | %+v", tenantId, variant)
 
 	return nil
 }
 
-func (v *Variant) VariantRemove(ctx context.Context, name string) (int64, error) {
-	v.logger.InfoF("VariantRemove received | %s", name)
+func (v *Variant) VariantSettings(ctx context.Context, tenantId, variantId int, settings *entities.VariantSettings) (int64, error) {
+	v.logger.WithContext(ctx).InfoF("VariantSettings received | %d | %d | %+v", tenantId, variantId, settings)
+	ctx, done := operation(ctx, "variants", "VariantSettings")
+	defer done()
+
+	query := `
+		UPDATE variants
+		SET reveal_answers = $3, reveal_explanations = $4, pass_mark = $5, opens_at = $6, closes_at = $7, restricted = $8, practice = $9
+		WHERE organization_id = $1 AND id = $2;
+	`
+	result, err := v.db.ExecContext(ctx, query, tenantId, variantId, settings.RevealAnswers, settings.RevealExplanations,
+		settings.PassMark, settings.OpensAt, settings.ClosesAt, settings.Restricted, settings.Practice)
+	if err != nil {
+		return 0, err
+	}
+
+	v.logger.WithContext(ctx).InfoF("VariantSettings success | %d | %d | %+v", tenantId, variantId, settings)
+
+	return result.RowsAffected()
+}
+
+func (v *Variant) VariantRemove(ctx context.Context, tenantId int, name string) (int64, error) {
+	v.logger.WithContext(ctx).InfoF("VariantRemove received | %d | %s", tenantId, name)
+	ctx, done := operation(ctx, "variants", "VariantRemove")
+	defer done()
 
 	tx, err := v.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -45,9 +100,9 @@ func (v *Variant) VariantRemove(ctx context.Context, name string) (int64, error)
 	}
 
 	variantQuery := `
-		DELETE FROM variants WHERE name = $1;
+		DELETE FROM variants WHERE organization_id = $1 AND name = $2;
 	`
-	result, err := tx.ExecContext(ctx, variantQuery, name)
+	result, err := tx.ExecContext(ctx, variantQuery, tenantId, name)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
@@ -69,17 +124,19 @@ func (v *Variant) VariantRemove(ctx context.Context, name string) (int64, error)
 		return 0, err
 	}
 
-	v.logger.InfoF("VariantRemove success | %s", name)
+	v.logger.WithContext(ctx).InfoF("VariantRemove success | %d | %s", tenantId, name)
 
 	return result.RowsAffected()
 }
 
-func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error) {
-	v.logger.Info("VariantRemove received")
+func (v *Variant) VariantList(ctx context.Context, tenantId int) ([]*entities.Variant, error) {
+	v.logger.WithContext(ctx).InfoF("VariantList received | %d", tenantId)
+	ctx, done := operation(ctx, "variants", "VariantList")
+	defer done()
 
 	query := `
 		SELECT
-			v.id, v.name,
+			v.id, v.name, v.reveal_answers, v.reveal_explanations, v.pass_mark, v.opens_at, v.closes_at, v.restricted, v.practice, v.author_id,
 			q.id AS question_id, q.question, q.answer,
 			(
 				SELECT json_agg(json_build_object('answer', a.answer))
@@ -89,10 +146,11 @@ func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error)
 			) AS answers
 		FROM variants v
 			LEFT JOIN questions q ON v.id = q.variant_id
+		WHERE v.organization_id = $1
 		ORDER BY v.id, q.id;
 	`
 
-	rows, err := v.db.QueryxContext(ctx, query)
+	rows, err := v.db.QueryxContext(ctx, query, tenantId)
 	if err != nil {
 		return nil, err
 	}
@@ -103,15 +161,23 @@ func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error)
 
 	for rows.Next() {
 		var (
-			variantId      sql.Null[int]
-			questionId     sql.Null[int]
-			variantName    sql.Null[string]
-			questionName   sql.Null[string]
-			questionAnswer sql.Null[string]
-			answersByte    []byte
+			variantId          sql.Null[int]
+			questionId         sql.Null[int]
+			variantName        sql.Null[string]
+			revealAnswers      bool
+			revealExplanations bool
+			passMark           float64
+			opensAt            sql.Null[time.Time]
+			closesAt           sql.Null[time.Time]
+			restricted         bool
+			practice           bool
+			authorId           sql.Null[int]
+			questionName       sql.Null[string]
+			questionAnswer     sql.Null[string]
+			answersByte        []byte
 		)
 
-		if err := rows.Scan(&variantId, &variantName, &questionId, &questionName, &questionAnswer, &answersByte); err != nil {
+		if err := rows.Scan(&variantId, &variantName, &revealAnswers, &revealExplanations, &passMark, &opensAt, &closesAt, &restricted, &practice, &authorId, &questionId, &questionName, &questionAnswer, &answersByte); err != nil {
 			return nil, err
 		}
 
@@ -121,9 +187,18 @@ func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error)
 			}
 
 			currentVariant = &entities.Variant{
-				Id:        variantId.V,
-				Name:      variantName.V,
-				Questions: make([]*entities.Question, 0),
+				Id:                 variantId.V,
+				OrganizationId:     tenantId,
+				Name:               variantName.V,
+				RevealAnswers:      revealAnswers,
+				RevealExplanations: revealExplanations,
+				PassMark:           passMark,
+				OpensAt:            nullTime(opensAt),
+				ClosesAt:           nullTime(closesAt),
+				Restricted:         restricted,
+				Practice:           practice,
+				AuthorId:           nullInt(authorId),
+				Questions:          make([]*entities.Question, 0),
 			}
 		}
 
@@ -148,18 +223,20 @@ func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error)
 		variants = append(variants, currentVariant)
 	}
 
-	v.logger.Info("VariantRemove received")
+	v.logger.WithContext(ctx).InfoF("VariantList success | %d", tenantId)
 
 	return variants, nil
 }
 
-func (v *Variant) VariantGet(ctx context.Context, name string) (*entities.Variant, error) {
-	v.logger.InfoF("VariantGet received | %s", name)
+func (v *Variant) VariantGet(ctx context.Context, tenantId int, name string) (*entities.Variant, error) {
+	v.logger.WithContext(ctx).InfoF("VariantGet received | %d | %s", tenantId, name)
+	ctx, done := operation(ctx, "variants", "VariantGet")
+	defer done()
 
 	query := `
 		SELECT
-			v.id, v.name,
-			q.id AS question_id, q.question, q.answer,
+			v.id, v.name, v.reveal_answers, v.reveal_explanations, v.pass_mark, v.opens_at, v.closes_at, v.restricted, v.practice, v.author_id,
+			q.id AS question_id, q.question, q.answer, q.points, q.multiple,
 			(
 				SELECT json_agg(json_build_object('answer', a.answer))
 				FROM questions_and_answers qaa
@@ -168,10 +245,10 @@ func (v *Variant) VariantGet(ctx context.Context, name string) (*entities.Varian
 			) AS answers
 		FROM variants v
 			LEFT JOIN questions q ON v.id = q.variant_id
-		WHERE v.name = $1;
+		WHERE v.organization_id = $1 AND v.name = $2;
 	`
 
-	rows, err := v.db.QueryxContext(ctx, query, name)
+	rows, err := v.db.QueryxContext(ctx, query, tenantId, name)
 	if err != nil {
 		return nil, err
 	}
@@ -182,20 +259,39 @@ func (v *Variant) VariantGet(ctx context.Context, name string) (*entities.Varian
 	var variantEntity = new(entities.Variant)
 	for rows.Next() {
 		var (
-			variantId   sql.Null[int]
-			variantName sql.Null[string]
-			questionId  sql.Null[int]
-			question    sql.Null[string]
-			answer      sql.Null[string]
-			answers     []byte
+			variantId          sql.Null[int]
+			variantName        sql.Null[string]
+			revealAnswers      bool
+			revealExplanations bool
+			passMark           float64
+			opensAt            sql.Null[time.Time]
+			closesAt           sql.Null[time.Time]
+			restricted         bool
+			practice           bool
+			authorId           sql.Null[int]
+			questionId         sql.Null[int]
+			question           sql.Null[string]
+			answer             sql.Null[string]
+			points             sql.Null[float64]
+			multiple           sql.Null[bool]
+			answers            []byte
 		)
 
-		if err := rows.Scan(&variantId, &variantName, &questionId, &question, &answer, &answers); err != nil {
+		if err := rows.Scan(&variantId, &variantName, &revealAnswers, &revealExplanations, &passMark, &opensAt, &closesAt, &restricted, &practice, &authorId, &questionId, &question, &answer, &points, &multiple, &answers); err != nil {
 			return nil, err
 		}
 
 		if variantEntity.Id == 0 && variantId.Valid {
 			variantEntity.Id = variantId.V
+			variantEntity.OrganizationId = tenantId
+			variantEntity.RevealAnswers = revealAnswers
+			variantEntity.RevealExplanations = revealExplanations
+			variantEntity.PassMark = passMark
+			variantEntity.OpensAt = nullTime(opensAt)
+			variantEntity.ClosesAt = nullTime(closesAt)
+			variantEntity.Restricted = restricted
+			variantEntity.Practice = practice
+			variantEntity.AuthorId = nullInt(authorId)
 		}
 		if variantEntity.Name == "" && variantName.Valid {
 			variantEntity.Name = variantName.V
@@ -208,6 +304,8 @@ func (v *Variant) VariantGet(ctx context.Context, name string) (*entities.Varian
 					Id:       qId,
 					Question: question.V,
 					Answer:   answer.V,
+					Points:   &points.V,
+					Multiple: multiple.V,
 				}
 				variantEntity.Questions = append(variantEntity.Questions, questionsMap[qId])
 			}
@@ -239,20 +337,23 @@ func (v *Variant) VariantGet(ctx context.Context, name string) (*entities.Varian
 		return nil, constants.ErrorVariantNotFound
 	}
 
-	v.logger.InfoF("VariantGet success | %s", name)
+	v.logger.WithContext(ctx).InfoF("VariantGet success | %d | %s", tenantId, name)
 
 	return variantEntity, nil
 }
 
-func (v *Variant) VariantStart(ctx context.Context, variantId, userId int) error {
-	v.logger.InfoF("VariantStart received | %d | %d", variantId, userId)
+func (v *Variant) VariantStart(ctx context.Context, tenantId, variantId, userId int) error {
+	v.logger.WithContext(ctx).InfoF("VariantStart received | %d | %d | %d", tenantId, variantId, userId)
+	ctx, done := operation(ctx, "variants", "VariantStart")
+	defer done()
 
 	var finish *time.Time
 	selectQuery := `
-		SELECT finish_at FROM testing
-		WHERE user_id = $1 AND variant_id = $2;
+		SELECT t.finish_at FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE t.user_id = $1 AND t.variant_id = $2 AND v.organization_id = $3;
 	`
-	if err := v.db.GetContext(ctx, &finish, selectQuery, userId, variantId); err != nil && !errors.Is(err, sql.ErrNoRows) {
+	if err := v.db.GetContext(ctx, &finish, selectQuery, userId, variantId, tenantId); err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
 
@@ -261,42 +362,147 @@ func (v *Variant) VariantStart(ctx context.Context, variantId, userId int) error
 	}
 
 	query := `
-		INSERT INTO testing (user_id, variant_id) VALUES ($1, $2);
+		INSERT INTO testing (user_id, variant_id)
+		SELECT $1, id FROM variants WHERE id = $2 AND organization_id = $3;
 	`
-	if _, err := v.db.ExecContext(ctx, query, userId, variantId); err != nil {
+	result, err := v.db.ExecContext(ctx, query, userId, variantId, tenantId)
+	if err != nil {
+		return translate(err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
 		return err
+	} else if rowsAffected == 0 {
+		return constants.ErrorVariantNotFound
 	}
 
-	v.logger.InfoF("VariantStart success | %d | %d", variantId, userId)
+	v.logger.WithContext(ctx).InfoF("VariantStart success | %d | %d | %d", tenantId, variantId, userId)
 
 	return nil
 }
 
-func (v *Variant) VariantResults(ctx context.Context, variantId, userId int) (*entities.Testing, error) {
-	v.logger.InfoF("VariantResults received | %d | %d", variantId, userId)
+// VariantResults finishes the attempt and scores it, once: an attempt that is
+// already finished is returned as it was, and finished reports which happened.
+func (v *Variant) VariantResults(ctx context.Context, tenantId, variantId, userId int) (*entities.Testing, bool, error) {
+	v.logger.WithContext(ctx).InfoF("VariantResults received | %d | %d | %d", tenantId, variantId, userId)
+	ctx, done := operation(ctx, "variants", "VariantResults")
+	defer done()
 
 	tx, err := v.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	var previous *time.Time
+	selectQuery := `
+		SELECT t.finish_at FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+		WHERE t.user_id = $1 AND t.variant_id = $2 AND v.organization_id = $3
+		FOR UPDATE OF t;
+	`
+	if err := tx.GetContext(ctx, &previous, selectQuery, userId, variantId, tenantId); err != nil {
+		tx.Rollback()
+		return nil, false, err
 	}
 
-	now := time.Now()
 	testingEntity := new(entities.Testing)
+	if previous != nil {
+		testingQuery := `
+			SELECT t.id, t.user_id, t.variant_id, t.correct_answers, t.penalty, t.score, t.max_score, t.start_at, t.finish_at
+			FROM testing t
+			WHERE t.user_id = $1 AND t.variant_id = $2
+		`
+		if err := tx.GetContext(ctx, testingEntity, testingQuery, userId, variantId); err != nil {
+			tx.Rollback()
+			return nil, false, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, false, err
+		}
+
+		v.logger.WithContext(ctx).InfoF("VariantResults success | %d | %d | %d | already finished", tenantId, variantId, userId)
+
+		return testingEntity, false, nil
+	}
+
+	now := time.Now()
 	finishTestingQuery := `
-		UPDATE testing SET finish_at = $1 
-		WHERE user_id = $2 AND variant_id = $3
-		RETURNING id, user_id, variant_id, correct_answers, start_at, finish_at;
+		UPDATE testing t
+		SET finish_at = $1,
+			max_score = (
+				SELECT COALESCE(SUM(q.points), 0) FROM questions q WHERE q.variant_id = t.variant_id
+			),
+			score = GREATEST(0, (
+				SELECT COALESCE(SUM(s.points), 0) FROM user_answer_scores s WHERE s.test_id = t.id
+			) - t.penalty)
+		WHERE t.user_id = $2 AND t.variant_id = $3 AND t.finish_at IS NULL
+			AND t.variant_id IN (SELECT id FROM variants WHERE organization_id = $4)
+		RETURNING t.id, t.user_id, t.variant_id, t.correct_answers, t.penalty, t.score, t.max_score, t.start_at, t.finish_at;
 	`
-	if err := v.db.GetContext(ctx, testingEntity, finishTestingQuery, now, userId, variantId); err != nil {
+	if err := tx.GetContext(ctx, testingEntity, finishTestingQuery, now, userId, variantId, tenantId); err != nil {
 		tx.Rollback()
-		return nil, err
+		return nil, false, err
+	}
+
+	outboxQuery := `
+		INSERT INTO webhook_outbox (organization_id, event, payload)
+		SELECT v.organization_id, $2, json_build_object(
+			'test_id', t.id, 'user_id', t.user_id, 'login', a.login, 'variant_id', v.id, 'variant', v.name,
+			'score', t.score, 'max_score', t.max_score,
+			'passed', v.pass_mark > 0 AND t.max_score > 0 AND t.score * 100 / t.max_score >= v.pass_mark,
+			'start_at', t.start_at, 'finish_at', t.finish_at
+		)
+		FROM testing t
+			JOIN variants v ON v.id = t.variant_id
+			JOIN auth a ON a.id = t.user_id
+		WHERE t.id = $1;
+	`
+	if _, err := tx.ExecContext(ctx, outboxQuery, testingEntity.ID, entities.WebhookAttemptFinished); err != nil {
+		tx.Rollback()
+		return nil, false, err
 	}
 
 	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	v.logger.WithContext(ctx).InfoF("VariantResults success | %d | %d | %d", tenantId, variantId, userId)
+
+	return testingEntity, true, nil
+}
+
+// VariantExpired is the only variant query that spans every organization: it feeds the background
+// finalizer, which then works on each variant within its own organization.
+func (v *Variant) VariantExpired(ctx context.Context) ([]*entities.Variant, error) {
+	v.logger.WithContext(ctx).Info("VariantExpired received")
+	ctx, done := operation(ctx, "variants", "VariantExpired")
+	defer done()
+
+	var variants = make([]*entities.Variant, 0)
+	query := `
+		SELECT DISTINCT v.id, v.organization_id, v.name, v.reveal_answers, v.reveal_explanations, v.pass_mark, v.opens_at, v.closes_at, v.restricted
+		FROM variants v
+			JOIN testing t ON t.variant_id = v.id
+		WHERE t.finish_at IS NULL AND v.closes_at <= now()
+	`
+	if err := v.db.SelectContext(ctx, &variants, query); err != nil {
 		return nil, err
 	}
 
-	v.logger.InfoF("VariantResults success | %d | %d", variantId, userId)
+	v.logger.WithContext(ctx).Info("VariantExpired success")
+
+	return variants, nil
+}
+
+func nullTime(t sql.Null[time.Time]) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.V
+}
 
-	return testingEntity, nil
+func nullInt(i sql.Null[int]) *int {
+	if !i.Valid {
+		return nil
+	}
+	return &i.V
 }
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"time"
+)
+
+type Webhooks struct {
+	db     *sqlx.DB
+	logger logger.Logging
+}
+
+func NewWebhooks(db *sqlx.DB, logger logger.Logging) *Webhooks {
+	return &Webhooks{db: db, logger: logger}
+}
+
+func (w *Webhooks) WebhookAdd(ctx context.Context, tenantId int, webhook *entities.Webhook) (*entities.Webhook, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookAdd received | %d | %s | %v", tenantId, webhook.Url, webhook.Events)
+	ctx, done := operation(ctx, "webhooks", "WebhookAdd")
+	defer done()
+
+	query := `
+		INSERT INTO webhooks (organization_id, url, secret, events)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at;
+	`
+	if err := w.db.QueryRowxContext(ctx, query, tenantId, webhook.Url, webhook.Secret, webhook.Events).Scan(&webhook.Id, &webhook.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookAdd success | %d | %d", tenantId, webhook.Id)
+
+	return webhook, nil
+}
+
+func (w *Webhooks) WebhookList(ctx context.Context, tenantId int) ([]*entities.Webhook, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookList received | %d", tenantId)
+	ctx, done := operation(ctx, "webhooks", "WebhookList")
+	defer done()
+
+	query := `
+		SELECT id, url, to_json(events), created_at FROM webhooks
+		WHERE organization_id = $1
+		ORDER BY id;
+	`
+	rows, err := w.db.QueryxContext(ctx, query, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks = make([]*entities.Webhook, 0)
+	for rows.Next() {
+		var (
+			webhook = new(entities.Webhook)
+			events  []byte
+		)
+		if err := rows.Scan(&webhook.Id, &webhook.Url, &events, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(events, &webhook.Events); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookList success | %d", tenantId)
+
+	return webhooks, nil
+}
+
+func (w *Webhooks) WebhookRemove(ctx context.Context, tenantId, webhookId int) (int64, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookRemove received | %d | %d", tenantId, webhookId)
+	ctx, done := operation(ctx, "webhooks", "WebhookRemove")
+	defer done()
+
+	query := `
+		DELETE FROM webhooks WHERE organization_id = $1 AND id = $2;
+	`
+	result, err := w.db.ExecContext(ctx, query, tenantId, webhookId)
+	if err != nil {
+		return 0, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookRemove success | %d | %d", tenantId, webhookId)
+
+	return result.RowsAffected()
+}
+
+func (w *Webhooks) WebhookDeliveries(ctx context.Context, tenantId, webhookId int) ([]*entities.WebhookDelivery, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookDeliveries received | %d | %d", tenantId, webhookId)
+	ctx, done := operation(ctx, "webhooks", "WebhookDeliveries")
+	defer done()
+
+	var exists int
+	webhookQuery := `
+		SELECT id FROM webhooks WHERE organization_id = $1 AND id = $2;
+	`
+	if err := w.db.GetContext(ctx, &exists, webhookQuery, tenantId, webhookId); err != nil {
+		return nil, err
+	}
+
+	var deliveries = make([]*entities.WebhookDelivery, 0)
+	query := `
+		SELECT d.id, d.webhook_id, o.event, d.status, d.attempts, d.status_code, d.error,
+			CASE WHEN d.status = 'pending' THEN d.next_attempt_at END AS next_attempt_at,
+			d.delivered_at, d.created_at
+		FROM webhook_deliveries d
+			JOIN webhook_outbox o ON o.id = d.outbox_id
+		WHERE d.webhook_id = $1
+		ORDER BY d.id DESC
+		LIMIT 100;
+	`
+	if err := w.db.SelectContext(ctx, &deliveries, query, webhookId); err != nil {
+		return nil, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookDeliveries success | %d | %d", tenantId, webhookId)
+
+	return deliveries, nil
+}
+
+// WebhookFanout spans every organization: it turns unprocessed outbox events into
+// one pending delivery per subscribed webhook of the event's organization.
+func (w *Webhooks) WebhookFanout(ctx context.Context, limit int) (int64, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookFanout received | %d", limit)
+	ctx, done := operation(ctx, "webhooks", "WebhookFanout")
+	defer done()
+
+	query := `
+		WITH batch AS (
+			SELECT id FROM webhook_outbox
+			WHERE processed_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), processed AS (
+			UPDATE webhook_outbox o SET processed_at = now()
+			FROM batch
+			WHERE o.id = batch.id
+			RETURNING o.id, o.organization_id, o.event
+		)
+		INSERT INTO webhook_deliveries (webhook_id, outbox_id)
+		SELECT wh.id, p.id FROM processed p
+			JOIN webhooks wh ON wh.organization_id = p.organization_id AND p.event = ANY(wh.events)
+		ON CONFLICT (webhook_id, outbox_id) DO NOTHING;
+	`
+	result, err := w.db.ExecContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookFanout success | %d", limit)
+
+	return result.RowsAffected()
+}
+
+// WebhookDue spans every organization as well. The picked deliveries are leased
+// until now + lease, so a concurrent dispatcher skips them and a dispatcher that
+// dies mid-send leaves them to be retried once the lease runs out.
+func (w *Webhooks) WebhookDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookCall, error) {
+	w.logger.WithContext(ctx).InfoF("WebhookDue received | %d", limit)
+	ctx, done := operation(ctx, "webhooks", "WebhookDue")
+	defer done()
+
+	var calls = make([]*entities.WebhookCall, 0)
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), leased AS (
+			UPDATE webhook_deliveries d SET next_attempt_at = $2
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.id, d.webhook_id, d.outbox_id, d.attempts
+		)
+		SELECT l.id AS delivery_id, l.attempts, wh.url, wh.secret, o.id AS outbox_id, o.event, o.payload, o.created_at
+		FROM leased l
+			JOIN webhooks wh ON wh.id = l.webhook_id
+			JOIN webhook_outbox o ON o.id = l.outbox_id
+		ORDER BY l.id;
+	`
+	if err := w.db.SelectContext(ctx, &calls, query, limit, time.Now().Add(lease)); err != nil {
+		return nil, err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookDue success | %d", len(calls))
+
+	return calls, nil
+}
+
+func (w *Webhooks) WebhookAttempt(ctx context.Context, deliveryId int64, status string, statusCode int, callErr string, nextAttemptAt time.Time) error {
+	w.logger.WithContext(ctx).InfoF("WebhookAttempt received | %d | %s | %d", deliveryId, status, statusCode)
+	ctx, done := operation(ctx, "webhooks", "WebhookAttempt")
+	defer done()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, status_code = $3, error = $4, next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN now() END
+		WHERE id = $1;
+	`
+	if _, err := w.db.ExecContext(ctx, query, deliveryId, status,
+		sql.Null[int]{V: statusCode, Valid: statusCode != 0},
+		sql.Null[string]{V: callErr, Valid: callErr != ""},
+		nextAttemptAt); err != nil {
+		return err
+	}
+
+	w.logger.WithContext(ctx).InfoF("WebhookAttempt success | %d | %s", deliveryId, status)
+
+	return nil
+}
@@ -6,14 +6,75 @@ import (
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
 	"quiz-service/internal/repository/postgres"
+	"time"
 )
 
+type AccessRepository interface {
+	AccessAdd(ctx context.Context, tenantId, variantId int, access *entities.Access) (*entities.Access, error)
+	AccessList(ctx context.Context, tenantId, variantId int) ([]*entities.Access, error)
+	AccessRemove(ctx context.Context, tenantId, variantId, accessId int) (int64, error)
+	AccessRedeem(ctx context.Context, tenantId, variantId, userId int, code string) error
+	AccessAllowed(ctx context.Context, tenantId, variantId, userId int) (bool, error)
+}
+
+type CertificateRepository interface {
+	CertificateIssue(ctx context.Context, tenantId, testId int, code string) (*entities.Certificate, error)
+	CertificateGet(ctx context.Context, tenantId, testId int) (*entities.Certificate, error)
+	CertificateVerify(ctx context.Context, code string) (*entities.Certificate, error)
+}
+
+type GraphRepository interface {
+	GraphVariants(ctx context.Context, tenantId int) ([]*entities.Variant, error)
+	GraphVariantsByIds(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Variant, error)
+	GraphQuestions(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Question, error)
+	GraphAnswers(ctx context.Context, tenantId int, questionIds []int) ([]*entities.Answer, error)
+	GraphAttempts(ctx context.Context, tenantId int, userIds []int) ([]*entities.Testing, error)
+	GraphUsers(ctx context.Context, tenantId int, userIds []int) ([]*entities.User, error)
+}
+
+type GroupsRepository interface {
+	GroupAdd(ctx context.Context, tenantId, ownerId int, name string) (*entities.Group, error)
+	GroupList(ctx context.Context, tenantId, ownerId int) ([]*entities.Group, error)
+	GroupGet(ctx context.Context, tenantId, groupId int) (*entities.Group, error)
+	GroupRemove(ctx context.Context, tenantId, groupId int) (int64, error)
+	GroupMembersAdd(ctx context.Context, tenantId, groupId int, logins []string) ([]string, error)
+	GroupMembersRemove(ctx context.Context, tenantId, groupId int, logins []string) (int64, error)
+	GroupAssign(ctx context.Context, tenantId, groupId, variantId int, dueAt *time.Time) error
+	GroupProgress(ctx context.Context, tenantId, groupId int) ([]*entities.GroupProgress, error)
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (*entities.Schema, error)
+	SchemaExpected() (uint, error)
+}
+
+type LiveRepository interface {
+	LiveAdd(ctx context.Context, tenantId, variantId, hostId int, pin string) (*entities.LiveSession, error)
+	LiveFinish(ctx context.Context, tenantId, sessionId int, results []*entities.LiveScore) error
+}
+
+type OrganizationsRepository interface {
+	OrganizationAdd(ctx context.Context, name, inviteCode string) (*entities.Organization, error)
+	OrganizationGet(ctx context.Context, name string) (*entities.Organization, error)
+	OrganizationByInvite(ctx context.Context, inviteCode string) (*entities.Organization, error)
+}
+
 type QuestionsRepository interface {
-	QuestionAdd(ctx context.Context, variantId int, question *entities.Question) error
-	QuestionRemove(ctx context.Context, variantId int, question string) (int64, error)
-	QuestionGet(ctx context.Context, variantId, questionId int) (*entities.Question, error)
-	QuestionCount(ctx context.Context, variantId int) (int, error)
-	QuestionAccept(ctx context.Context, answer string, userId, testId, variantId int) error
+	QuestionAdd(ctx context.Context, tenantId, variantId int, question *entities.Question) error
+	QuestionRemove(ctx context.Context, tenantId, variantId int, question string) (int64, error)
+	QuestionGet(ctx context.Context, tenantId, variantId, questionId int) (*entities.Question, error)
+	QuestionCount(ctx context.Context, tenantId, variantId int) (int, error)
+	QuestionAccept(ctx context.Context, tenantId, testId, questionId int, answers []string, points float64, correct bool) error
+	QuestionHint(ctx context.Context, tenantId, testId, variantId, questionId int) (*entities.Hint, error)
+}
+
+type PracticeRepository interface {
+	PracticeStart(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error)
+	PracticeGet(ctx context.Context, tenantId, userId, variantId int) (*entities.Practice, error)
+	PracticeAccept(ctx context.Context, tenantId, practiceId, questionId int, answers []string, correct bool) error
+	PracticeFinish(ctx context.Context, tenantId, practiceId int) (*entities.Practice, error)
+	PracticeResults(ctx context.Context, tenantId, userId, variantId int) (*entities.Practice, error)
 }
 
 type RegisterRepository interface {
@@ -22,37 +83,72 @@ type RegisterRepository interface {
 }
 
 type TestingRepository interface {
-	TestGet(ctx context.Context, userId, variantId int) (*entities.Testing, error)
+	TestGet(ctx context.Context, tenantId, userId, variantId int) (*entities.Testing, error)
+	TestReview(ctx context.Context, tenantId, testId int) ([]*entities.Review, error)
+	TestUnfinished(ctx context.Context, tenantId, variantId int) ([]*entities.Testing, error)
 }
 
 type UserRepository interface {
 	Quit(ctx context.Context, uuid string) (int64, error)
 	Authenticated(ctx context.Context, uuid string) (*entities.User, error)
+	UserPasswordSet(ctx context.Context, login, password string) (int64, error)
+	UserRoleSet(ctx context.Context, login, role string) (int64, error)
+	UserDisable(ctx context.Context, login string) (int64, error)
 }
 
 type VariantRepository interface {
-	VariantAdd(ctx context.Context, name string) error
-	VariantRemove(ctx context.Context, name string) (int64, error)
-	VariantList(ctx context.Context) ([]*entities.Variant, error)
-	VariantGet(ctx context.Context, name string) (*entities.Variant, error)
-	VariantStart(ctx context.Context, variantId, userId int) error
-	VariantResults(ctx context.Context, variantId, userId int) (*entities.Testing, error)
+	VariantAdd(ctx context.Context, tenantId int, variant *entities.Variant) error
+	VariantSettings(ctx context.Context, tenantId, variantId int, settings *entities.VariantSettings) (int64, error)
+	VariantRemove(ctx context.Context, tenantId int, name string) (int64, error)
+	VariantList(ctx context.Context, tenantId int) ([]*entities.Variant, error)
+	VariantGet(ctx context.Context, tenantId int, name string) (*entities.Variant, error)
+	VariantStart(ctx context.Context, tenantId, variantId, userId int) error
+	VariantResults(ctx context.Context, tenantId, variantId, userId int) (*entities.Testing, bool, error)
+	VariantExpired(ctx context.Context) ([]*entities.Variant, error)
+}
+
+type WebhooksRepository interface {
+	WebhookAdd(ctx context.Context, tenantId int, webhook *entities.Webhook) (*entities.Webhook, error)
+	WebhookList(ctx context.Context, tenantId int) ([]*entities.Webhook, error)
+	WebhookRemove(ctx context.Context, tenantId, webhookId int) (int64, error)
+	WebhookDeliveries(ctx context.Context, tenantId, webhookId int) ([]*entities.WebhookDelivery, error)
+	WebhookFanout(ctx context.Context, limit int) (int64, error)
+	WebhookDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookCall, error)
+	WebhookAttempt(ctx context.Context, deliveryId int64, status string, statusCode int, callErr string, nextAttemptAt time.Time) error
 }
 
 type Repository struct {
+	AccessRepository
+	CertificateRepository
+	GraphRepository
+	GroupsRepository
+	HealthRepository
+	LiveRepository
+	OrganizationsRepository
 	QuestionsRepository
+	PracticeRepository
 	RegisterRepository
 	TestingRepository
 	UserRepository
 	VariantRepository
+	WebhooksRepository
 }
 
 func NewRepository(db *sqlx.DB, logger *logger.Logger) *Repository {
 	return &Repository{
-		QuestionsRepository: postgres.NewQuestions(db, logger),
-		RegisterRepository:  postgres.NewRegister(db, logger),
-		TestingRepository:   postgres.NewTesting(db, logger),
-		UserRepository:      postgres.NewUser(db, logger),
-		VariantRepository:   postgres.NewVariant(db, logger),
+		AccessRepository:        postgres.NewAccess(db, logger),
+		CertificateRepository:   postgres.NewCertificate(db, logger),
+		GraphRepository:         postgres.NewGraph(db, logger),
+		GroupsRepository:        postgres.NewGroups(db, logger),
+		HealthRepository:        postgres.NewHealth(db, logger),
+		LiveRepository:          postgres.NewLive(db, logger),
+		OrganizationsRepository: postgres.NewOrganizations(db, logger),
+		QuestionsRepository:     postgres.NewQuestions(db, logger),
+		PracticeRepository:      postgres.NewPractice(db, logger),
+		RegisterRepository:      postgres.NewRegister(db, logger),
+		TestingRepository:       postgres.NewTesting(db, logger),
+		UserRepository:          postgres.NewUser(db, logger),
+		VariantRepository:       postgres.NewVariant(db, logger),
+		WebhooksRepository:      postgres.NewWebhooks(db, logger),
 	}
 }
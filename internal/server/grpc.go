@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"net"
+	"quiz-service/init/config"
+	"quiz-service/init/logger"
+	"quiz-service/internal/server/grpc/handlers"
+	"quiz-service/internal/service"
+	quizv1 "quiz-service/pkg/pb/quiz/v1"
+)
+
+type GRPCServer struct {
+	server *grpc.Server
+	port   int
+}
+
+// NewGRPCServer serves the same service.Service as the HTTP server, so both
+// share in-memory state such as live sessions and proctor events.
+func NewGRPCServer(cfg *config.Config, service *service.Service, grpcLogger *logger.Logger) *GRPCServer {
+	handler := handlers.NewHandler(service, grpcLogger)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(handler.Authenticated))
+	quizv1.RegisterQuizServiceServer(server, handler)
+
+	return &GRPCServer{server: server, port: cfg.GrpcPort}
+}
+
+func (s *GRPCServer) Run() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return err
+	}
+	return s.server.Serve(listener)
+}
+
+// Shutdown waits for in-flight calls like http.Server.Shutdown does and cuts
+// them off once ctx is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"quiz-service/internal/entities"
+	quizv1 "quiz-service/pkg/pb/quiz/v1"
+	"time"
+)
+
+func toUser(user *entities.User) *quizv1.User {
+	return &quizv1.User{
+		Id:             int32(user.ID),
+		OrganizationId: int32(user.OrganizationId),
+		Uuid:           user.UUID,
+		Login:          user.Login,
+	}
+}
+
+func toVariant(variant *entities.Variant) *quizv1.Variant {
+	questions := make([]*quizv1.Question, 0, len(variant.Questions))
+	for _, question := range variant.Questions {
+		questions = append(questions, toQuestion(question))
+	}
+
+	return &quizv1.Variant{
+		Id:   int32(variant.Id),
+		Name: variant.Name,
+		Settings: &quizv1.VariantSettings{
+			RevealAnswers:      variant.RevealAnswers,
+			RevealExplanations: variant.RevealExplanations,
+			PassMark:           variant.PassMark,
+			OpensAt:            toTimestamp(variant.OpensAt),
+			ClosesAt:           toTimestamp(variant.ClosesAt),
+			Restricted:         variant.Restricted,
+			Practice:           variant.Practice,
+		},
+		Status:    variant.Status,
+		Questions: questions,
+	}
+}
+
+func fromSettings(settings *quizv1.VariantSettings) *entities.VariantSettings {
+	return &entities.VariantSettings{
+		RevealAnswers:      settings.GetRevealAnswers(),
+		RevealExplanations: settings.GetRevealExplanations(),
+		PassMark:           settings.GetPassMark(),
+		OpensAt:            fromTimestamp(settings.GetOpensAt()),
+		ClosesAt:           fromTimestamp(settings.GetClosesAt()),
+		Restricted:         settings.GetRestricted(),
+		Practice:           settings.GetPractice(),
+	}
+}
+
+// toQuestion copies every field it is given, so handlers pass takers the
+// question's view rather than the question itself.
+func toQuestion(question *entities.Question) *quizv1.Question {
+	answers := make([]*quizv1.Answer, 0, len(question.Answers))
+	for _, answer := range question.Answers {
+		answers = append(answers, &quizv1.Answer{Answer: answer.Answer, Feedback: answer.Feedback, Correct: answer.Correct})
+	}
+	hints := make([]*quizv1.Hint, 0, len(question.Hints))
+	for _, hint := range question.Hints {
+		hints = append(hints, toHint(hint))
+	}
+
+	return &quizv1.Question{
+		Id:             int32(question.Id),
+		Question:       question.Question,
+		Answer:         question.Answer,
+		Explanation:    question.Explanation,
+		Points:         question.Points,
+		NegativePoints: question.NegativePoints,
+		Multiple:       question.Multiple,
+		Answers:        answers,
+		Hints:          hints,
+	}
+}
+
+func fromQuestion(question *quizv1.Question) *entities.Question {
+	answers := make([]*entities.Answer, 0, len(question.GetAnswers()))
+	for _, answer := range question.GetAnswers() {
+		answers = append(answers, &entities.Answer{Answer: answer.GetAnswer(), Feedback: answer.GetFeedback(), Correct: answer.GetCorrect()})
+	}
+	var hints []*entities.Hint
+	for _, hint := range question.GetHints() {
+		hints = append(hints, &entities.Hint{Hint: hint.GetHint(), Penalty: hint.GetPenalty()})
+	}
+
+	return &entities.Question{
+		Question:       question.GetQuestion(),
+		Answer:         question.GetAnswer(),
+		Explanation:    question.GetExplanation(),
+		Points:         question.Points,
+		NegativePoints: question.GetNegativePoints(),
+		Multiple:       question.GetMultiple(),
+		Answers:        answers,
+		Hints:          hints,
+	}
+}
+
+func toHint(hint *entities.Hint) *quizv1.Hint {
+	return &quizv1.Hint{Id: int32(hint.Id), Hint: hint.Hint, Penalty: hint.Penalty}
+}
+
+func toAttempt(testing *entities.Testing) *quizv1.Attempt {
+	return &quizv1.Attempt{
+		Id:             int32(testing.ID),
+		VariantId:      int32(testing.VariantId),
+		CorrectAnswers: int32(testing.CorrectAnswers),
+		Penalty:        testing.Penalty,
+		Score:          testing.Score,
+		MaxScore:       testing.MaxScore,
+		Passed:         testing.Passed,
+		StartAt:        timestamppb.New(testing.StartAt),
+		FinishAt:       toTimestamp(testing.FinishAt),
+	}
+}
+
+func toReview(review *entities.Review) *quizv1.Review {
+	return &quizv1.Review{
+		QuestionId:    int32(review.QuestionId),
+		Question:      review.Question,
+		Answer:        review.Answer,
+		Correct:       review.Correct,
+		Points:        review.Points,
+		CorrectAnswer: review.CorrectAnswer,
+		Explanation:   review.Explanation,
+		Feedback:      review.Feedback,
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
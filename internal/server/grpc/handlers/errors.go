@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"quiz-service/pkg/constants"
+)
+
+// codesByError mirrors the http statuses the gin handlers answer with.
+var codesByError = map[error]codes.Code{
+	constants.ErrorUserAlreadyExists:    codes.AlreadyExists,
+	constants.ErrorUserNotFound:         codes.Unauthenticated,
+	constants.ErrorUserNotAuthorized:    codes.Unauthenticated,
+	constants.ErrorOrganizationNotFound: codes.NotFound,
+	constants.ErrorInviteNotFound:       codes.NotFound,
+
+	constants.ErrorVariantAlreadyExists: codes.AlreadyExists,
+	constants.ErrorVariantTooLong:       codes.InvalidArgument,
+	constants.ErrorVariantNotFound:      codes.NotFound,
+	constants.ErrorNoVariantsYet:        codes.NotFound,
+	constants.ErrorVariantCompleted:     codes.FailedPrecondition,
+	constants.ErrorVariantNotOpen:       codes.PermissionDenied,
+	constants.ErrorVariantClosed:        codes.PermissionDenied,
+	constants.ErrorVariantSchedule:      codes.InvalidArgument,
+	constants.ErrorVariantRestricted:    codes.PermissionDenied,
+	constants.ErrorPracticeDisabled:     codes.PermissionDenied,
+	constants.ErrorVariantNotAuthor:     codes.PermissionDenied,
+
+	constants.ErrorQuestionAlreadyExists: codes.AlreadyExists,
+	constants.ErrorQuestionNotFound:      codes.NotFound,
+	constants.ErrorQuestionLimitExceeded: codes.InvalidArgument,
+	constants.ErrorQuestionNotMultiple:   codes.InvalidArgument,
+	constants.ErrorNoHintsLeft:           codes.FailedPrecondition,
+	constants.ErrorQuestionAnswered:      codes.AlreadyExists,
+
+	constants.ErrorTestNotFound:    codes.NotFound,
+	constants.ErrorTestNotFinished: codes.FailedPrecondition,
+
+	context.DeadlineExceeded: codes.DeadlineExceeded,
+}
+
+func statusError(err error) error {
+	for target, code := range codesByError {
+		if errors.Is(err, target) {
+			return status.Error(code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/constants"
+	quizv1 "quiz-service/pkg/pb/quiz/v1"
+)
+
+// UserMetadata is the metadata key carrying the user's uuid, the gRPC
+// counterpart of the :userId route segment.
+const UserMetadata = "x-user-id"
+
+// RequestIDMetadata correlates a call with the logs, like the X-Request-ID
+// header does over http. A missing id is generated and sent back as a header.
+const RequestIDMetadata = "x-request-id"
+
+type userKey struct{}
+
+type Handler struct {
+	quizv1.UnimplementedQuizServiceServer
+
+	service *service.Service
+
+	logger logger.Logging
+}
+
+func NewHandler(service *service.Service, logger logger.Logging) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Authenticated resolves the caller from UserMetadata for every method except
+// Register and Login, the same way the Authenticated gin middleware does.
+func (h *Handler) Authenticated(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestId := uuid.NewString()
+	if ids := md.Get(RequestIDMetadata); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= 128 {
+		requestId = ids[0]
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestId))
+	ctx = logger.ContextWithFields(ctx, map[string]interface{}{
+		logger.RequestIDField: requestId,
+		logger.RouteField:     info.FullMethod,
+	})
+
+	h.logger.WithContext(ctx).InfoF("%s received", info.FullMethod)
+
+	if info.FullMethod == quizv1.QuizService_Register_FullMethodName || info.FullMethod == quizv1.QuizService_Login_FullMethodName {
+		return next(ctx, req)
+	}
+
+	uuids := md.Get(UserMetadata)
+	if len(uuids) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "missing %s metadata", UserMetadata)
+	}
+
+	user, err := h.service.UserService.Authenticated(ctx, uuids[0])
+	if err != nil {
+		if errors.Is(err, constants.ErrorUserNotFound) || errors.Is(err, constants.ErrorUserNotAuthorized) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ctx = logger.ContextWithFields(ctx, map[string]interface{}{logger.UserIDField: user.ID})
+	return next(context.WithValue(ctx, userKey{}, user), req)
+}
+
+func userFrom(ctx context.Context) *entities.User {
+	return ctx.Value(userKey{}).(*entities.User)
+}
+
+// validate applies the entity's binding tags, so gRPC requests pass the same
+// checks as JSON bodies.
+func validate(entity any) error {
+	if err := binding.Validator.ValidateStruct(entity); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func (h *Handler) variant(ctx context.Context, name string) (*entities.Variant, error) {
+	variant, err := h.service.VariantService.VariantGet(ctx, userFrom(ctx).OrganizationId, name)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return variant, nil
+}
+
+// authored resolves a variant the caller may change: its author's or, for an
+// admin, any in the organization.
+func (h *Handler) authored(ctx context.Context, name string) (*entities.Variant, error) {
+	variant, err := h.variant(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.VariantService.VariantAuthor(variant, userFrom(ctx)); err != nil {
+		return nil, statusError(err)
+	}
+	return variant, nil
+}
+
+// readable resolves a variant the caller may read: restricted ones only when
+// invited, or for their author and admins.
+func (h *Handler) readable(ctx context.Context, name string) (*entities.Variant, error) {
+	variant, err := h.variant(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.VariantService.VariantAccess(ctx, variant, userFrom(ctx)); err != nil {
+		return nil, statusError(err)
+	}
+	return variant, nil
+}
+
+func (h *Handler) Register(ctx context.Context, req *quizv1.RegisterRequest) (*quizv1.User, error) {
+	registerEntity := &entities.Register{Login: req.GetLogin(), Password: req.GetPassword(), Invite: req.GetInvite()}
+	if err := validate(registerEntity); err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.RegisterService.Register(ctx, registerEntity)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toUser(user), nil
+}
+
+func (h *Handler) Login(ctx context.Context, req *quizv1.LoginRequest) (*quizv1.User, error) {
+	loginEntity := &entities.Login{Login: req.GetLogin(), Password: req.GetPassword()}
+	if err := validate(loginEntity); err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.RegisterService.Login(ctx, loginEntity)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toUser(user), nil
+}
+
+func (h *Handler) Quit(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := h.service.UserService.Quit(ctx, userFrom(ctx).UUID); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
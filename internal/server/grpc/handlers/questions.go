@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"quiz-service/internal/entities"
+	quizv1 "quiz-service/pkg/pb/quiz/v1"
+)
+
+func (h *Handler) AddQuestion(ctx context.Context, req *quizv1.AddQuestionRequest) (*emptypb.Empty, error) {
+	questionEntity := fromQuestion(req.GetQuestion())
+	if err := validate(questionEntity); err != nil {
+		return nil, err
+	}
+
+	variant, err := h.authored(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.QuestionsService.QuestionAdd(ctx, variant.OrganizationId, variant.Id, questionEntity); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) RemoveQuestion(ctx context.Context, req *quizv1.RemoveQuestionRequest) (*emptypb.Empty, error) {
+	questionEntity := &entities.QuestionRemove{VariantName: req.GetVariantName(), Question: req.GetQuestion()}
+	if err := validate(questionEntity); err != nil {
+		return nil, err
+	}
+
+	variant, err := h.authored(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.QuestionsService.QuestionRemove(ctx, variant.OrganizationId, variant.Id, questionEntity); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) GetQuestion(ctx context.Context, req *quizv1.QuestionRequest) (*quizv1.Question, error) {
+	variant, err := h.readable(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := h.service.QuestionsService.QuestionGet(ctx, variant.OrganizationId, variant.Id, int(req.GetQuestionId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toQuestion(h.service.QuestionsService.QuestionView(variant, userFrom(ctx), question)), nil
+}
+
+func (h *Handler) AnswerQuestion(ctx context.Context, req *quizv1.AnswerQuestionRequest) (*emptypb.Empty, error) {
+	answerEntity := &entities.UserAnswer{Answer: req.GetAnswer(), Answers: req.GetAnswers()}
+	if err := validate(answerEntity); err != nil {
+		return nil, err
+	}
+
+	variant, err := h.variant(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.QuestionsService.QuestionAccept(ctx, variant, userFrom(ctx), int(req.GetQuestionId()), answerEntity); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) TakeHint(ctx context.Context, req *quizv1.QuestionRequest) (*quizv1.Hint, error) {
+	variant, err := h.variant(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	hint, err := h.service.QuestionsService.QuestionHint(ctx, variant, userFrom(ctx), int(req.GetQuestionId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toHint(hint), nil
+}
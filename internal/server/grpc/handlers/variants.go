@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+	quizv1 "quiz-service/pkg/pb/quiz/v1"
+)
+
+func (h *Handler) AddVariant(ctx context.Context, req *quizv1.AddVariantRequest) (*emptypb.Empty, error) {
+	settings := fromSettings(req.GetSettings())
+	variantEntity := &entities.Variant{
+		Name:               req.GetName(),
+		RevealAnswers:      settings.RevealAnswers,
+		RevealExplanations: settings.RevealExplanations,
+		PassMark:           settings.PassMark,
+		OpensAt:            settings.OpensAt,
+		ClosesAt:           settings.ClosesAt,
+		Restricted:         settings.Restricted,
+		Practice:           settings.Practice,
+	}
+	if err := validate(variantEntity); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.VariantService.VariantAdd(ctx, userFrom(ctx).OrganizationId, userFrom(ctx).ID, variantEntity); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListVariants(ctx context.Context, _ *emptypb.Empty) (*quizv1.ListVariantsResponse, error) {
+	response := new(quizv1.ListVariantsResponse)
+
+	variants, err := h.service.VariantService.VariantList(ctx, userFrom(ctx).OrganizationId)
+	if err != nil {
+		if errors.Is(err, constants.ErrorNoVariantsYet) {
+			return response, nil
+		}
+		return nil, statusError(err)
+	}
+
+	variants, err = h.service.VariantService.VariantAllowed(ctx, variants, userFrom(ctx))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	for _, variant := range variants {
+		response.Variants = append(response.Variants, toVariant(h.service.VariantService.VariantView(variant, userFrom(ctx))))
+	}
+
+	return response, nil
+}
+
+func (h *Handler) GetVariant(ctx context.Context, req *quizv1.VariantRequest) (*quizv1.Variant, error) {
+	variant, err := h.readable(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+	return toVariant(h.service.VariantService.VariantView(variant, userFrom(ctx))), nil
+}
+
+func (h *Handler) RemoveVariant(ctx context.Context, req *quizv1.VariantRequest) (*emptypb.Empty, error) {
+	variant, err := h.authored(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.VariantService.VariantRemove(ctx, variant.OrganizationId, variant.Name); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) UpdateVariantSettings(ctx context.Context, req *quizv1.UpdateVariantSettingsRequest) (*emptypb.Empty, error) {
+	settings := fromSettings(req.GetSettings())
+	if err := validate(settings); err != nil {
+		return nil, err
+	}
+
+	variant, err := h.authored(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.VariantService.VariantSettings(ctx, variant.OrganizationId, variant.Id, settings); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) StartAttempt(ctx context.Context, req *quizv1.VariantRequest) (*quizv1.Variant, error) {
+	variant, err := h.variant(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.VariantService.VariantStart(ctx, variant, userFrom(ctx)); err != nil {
+		return nil, statusError(err)
+	}
+
+	return toVariant(h.service.VariantService.VariantView(variant, userFrom(ctx))), nil
+}
+
+func (h *Handler) FinishAttempt(ctx context.Context, req *quizv1.VariantRequest) (*quizv1.Attempt, error) {
+	variant, err := h.variant(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	testing, err := h.service.VariantService.VariantResults(ctx, variant, userFrom(ctx))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toAttempt(testing), nil
+}
+
+func (h *Handler) ReviewAttempt(ctx context.Context, req *quizv1.VariantRequest) (*quizv1.ReviewAttemptResponse, error) {
+	variant, err := h.variant(ctx, req.GetVariantName())
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := h.service.VariantService.VariantReview(ctx, variant, userFrom(ctx).ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := new(quizv1.ReviewAttemptResponse)
+	for _, review := range reviews {
+		response.Reviews = append(response.Reviews, toReview(review))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,49 @@
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+	"quiz-service/pkg/openapi"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed swagger.html
+var swaggerUI []byte
+
+// OpenAPI serves the document as JSON.
+func OpenAPI(document *openapi.Document) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, document)
+	}
+}
+
+// SwaggerUI serves a Swagger UI page that loads openapi.json next to it.
+func SwaggerUI() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUI)
+	}
+}
+
+// Missing lists the registered routes under entry that the document doesn't
+// describe, as "METHOD path". The docs test fails while it isn't empty, so a
+// new route can't ship undocumented; the server only logs it.
+func Missing(document *openapi.Document, routes gin.RoutesInfo, entry string) []string {
+	var missing []string
+	for _, route := range routes {
+		path, ok := strings.CutPrefix(route.Path, entry)
+		if !ok {
+			continue
+		}
+		if path == "" {
+			path = "/"
+		}
+		if !document.Has(route.Method, path) {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
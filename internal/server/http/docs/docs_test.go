@@ -0,0 +1,36 @@
+package docs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"quiz-service/init/config"
+	"quiz-service/init/logger"
+	"quiz-service/internal/server/http/docs"
+	"quiz-service/internal/server/http/router"
+)
+
+// TestMissing registers every route the way the server does, without a
+// database, and fails on any the OpenAPI document doesn't describe.
+func TestMissing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log, err := logger.NewLogger(ctx, false, config.LogFile{Dir: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const entry = "/quiz"
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	components := router.InitRouterAndComponents(engine.Group(entry), nil, &config.Config{Entry: entry, PasswordSalt: "salt"}, log, log, log)
+	components.Routes()
+	components.Probes(engine)
+
+	if missing := docs.Missing(components.Document(), engine.Routes(), entry); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI document: %v", missing)
+	}
+}
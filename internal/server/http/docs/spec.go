@@ -0,0 +1,340 @@
+package docs
+
+import (
+	"net/http"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/server/http/handlers"
+	"quiz-service/pkg/openapi"
+	"strconv"
+)
+
+const (
+	title   = "quiz-service"
+	version = "1.0.0"
+
+	bearer = "bearer"
+)
+
+// practiceStart is the data of a started practice attempt.
+type practiceStart struct {
+	Practice *entities.Practice `json:"practice"`
+	Variant  *entities.Variant  `json:"variant"`
+}
+
+// Spec documents every route registered by router.Routes. Paths are relative to
+// entry, which is the document's only server.
+func Spec(entry string) *openapi.Document {
+	d := openapi.New(title, version, entry)
+
+	d.Param("userId", &openapi.Schema{Type: "string", Format: "uuid"})
+	d.Param("groupId", openapi.Integer())
+	d.Param("webhookId", openapi.Integer())
+	d.Param("accessId", openapi.Integer())
+	d.Param("questionId", openapi.Integer())
+
+	d.Security(bearer, &openapi.SecurityScheme{Type: "http", Scheme: "bearer", Description: "uuid returned by /users and /sessions"})
+
+	d.Add(http.MethodGet, "/", page(d, "Auth", "Registration and login page"))
+	d.Add(http.MethodGet, "/openapi.json", op(d, "Docs", "This document").content(http.StatusOK, "application/json", &openapi.Schema{Type: "object"}).build())
+	d.Add(http.MethodGet, "/docs", page(d, "Docs", "Swagger UI"))
+	d.Add(http.MethodGet, "/:userId/variant/", page(d, "Variants", "Variants page"))
+	d.Add(http.MethodGet, "/:userId/variant/:variantName/", page(d, "Variants", "Testing page"))
+
+	v1(d)
+	legacy(d)
+
+	return d
+}
+
+// v1 documents the /api/v1 routes. Every route but the public ones takes the
+// user from the bearer token.
+func v1(d *openapi.Document) {
+	add := func(method, path string, operation *openapi.Operation) {
+		d.Add(method, "/api/v1"+path, operation)
+	}
+
+	add(http.MethodPost, "/users", op(d, "Auth", "Register a user by invite code or in the default organization").
+		body(entities.Register{}).ok(http.StatusCreated, entities.User{}).fails(400, 404, 409).build())
+	add(http.MethodPost, "/sessions", op(d, "Auth", "Log in").
+		body(entities.Login{}).ok(http.StatusCreated, entities.User{}).fails(400, 401).build())
+	add(http.MethodDelete, "/sessions", op(d, "Auth", "Log out").auth().
+		ok(http.StatusOK, nil).fails(401, 404).build())
+	add(http.MethodGet, "/certificates/:code", op(d, "Certificates", "Verify a certificate by its code").
+		ok(http.StatusOK, entities.Certificate{}).fails(404).build())
+	add(http.MethodPost, "/graphql", op(d, "GraphQL", "Query variants, questions and attempts of the organization").auth().
+		body(entities.GraphQuery{}).content(http.StatusOK, "application/json", d.Schema(entities.GraphResult{})).fails(400, 401).build())
+	add(http.MethodPost, "/organizations", op(d, "Organizations", "Create an organization and its invite code").auth().
+		body(entities.Organization{}).ok(http.StatusCreated, entities.Organization{}).fails(400, 401, 403, 409).build())
+
+	add(http.MethodPost, "/groups", op(d, "Groups", "Create a group").auth().
+		body(entities.Group{}).ok(http.StatusCreated, entities.Group{}).fails(400, 401, 409).build())
+	add(http.MethodGet, "/groups", op(d, "Groups", "List own groups").auth().
+		ok(http.StatusOK, []*entities.Group{}).fails(401).build())
+	add(http.MethodGet, "/groups/:groupId", op(d, "Groups", "Group with members and assignments").auth().
+		ok(http.StatusOK, entities.Group{}).fails(400, 401, 403, 404).build())
+	add(http.MethodDelete, "/groups/:groupId", op(d, "Groups", "Remove a group").auth().
+		ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/groups/:groupId/members", op(d, "Groups", "Add members by login").auth().
+		body(entities.GroupMembers{}).ok(http.StatusOK, entities.GroupMembersResult{}).fails(400, 401, 403, 404).build())
+	add(http.MethodDelete, "/groups/:groupId/members/:login", op(d, "Groups", "Remove a member").auth().
+		ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/groups/:groupId/assignments", op(d, "Groups", "Assign a variant to the group").auth().
+		body(entities.GroupAssignment{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/groups/:groupId/progress", op(d, "Groups", "Progress of every member on every assignment").auth().
+		ok(http.StatusOK, []*entities.GroupProgress{}).fails(400, 401, 403, 404).build())
+
+	add(http.MethodPost, "/webhooks", op(d, "Webhooks", "Subscribe a url to organization events").auth().
+		body(entities.Webhook{}).ok(http.StatusCreated, entities.Webhook{}).fails(400, 401, 403).build())
+	add(http.MethodGet, "/webhooks", op(d, "Webhooks", "List the organization's webhooks").auth().
+		ok(http.StatusOK, []*entities.Webhook{}).fails(401, 403).build())
+	add(http.MethodDelete, "/webhooks/:webhookId", op(d, "Webhooks", "Remove a webhook").auth().
+		ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/webhooks/:webhookId/deliveries", op(d, "Webhooks", "Latest deliveries of a webhook").auth().
+		ok(http.StatusOK, []*entities.WebhookDelivery{}).fails(400, 401, 403, 404).build())
+
+	add(http.MethodGet, "/live/:pin/ws", op(d, "Live", "Join a live session over WebSocket").auth().
+		describe(http.StatusSwitchingProtocols, "WebSocket of LiveMessage frames; participants send LiveAnswer frames").
+		schema(entities.LiveMessage{}).schema(entities.LiveAnswer{}).fails(401, 403, 404).build())
+	add(http.MethodPost, "/live/:pin/questions", op(d, "Live", "Close the open question or open the next one").auth().
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/live/:pin", op(d, "Live", "Finish the live session").auth().
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/variants", op(d, "Variants", "Create a variant").auth().
+		body(entities.Variant{}).ok(http.StatusCreated, nil).fails(400, 401, 409).build())
+	add(http.MethodGet, "/variants", op(d, "Variants", "List the organization's variants").auth().
+		ok(http.StatusOK, []*entities.Variant{}).fails(401, 404).build())
+	add(http.MethodGet, "/variants/:variantName", op(d, "Variants", "Variant with its questions").auth().
+		ok(http.StatusOK, entities.Variant{}).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/variants/:variantName", op(d, "Variants", "Remove a variant").auth().
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodPut, "/variants/:variantName/settings", op(d, "Variants", "Change variant settings").auth().
+		body(entities.VariantSettings{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/variants/:variantName/attempt", op(d, "Testing", "Start an attempt").auth().
+		ok(http.StatusOK, entities.Variant{}).fails(401, 403, 404, 409).build())
+	add(http.MethodPost, "/variants/:variantName/results", op(d, "Testing", "Finish the attempt and get the results").auth().
+		ok(http.StatusOK, entities.Testing{}).fails(401, 404).build())
+	add(http.MethodGet, "/variants/:variantName/review", op(d, "Testing", "Review a finished attempt").auth().
+		ok(http.StatusOK, []*entities.Review{}).fails(401, 404, 409).build())
+	add(http.MethodGet, "/variants/:variantName/certificate", op(d, "Certificates", "Download the PDF certificate").auth().
+		content(http.StatusOK, "application/pdf", &openapi.Schema{Type: "string", Format: "binary"}).fails(401, 404).build())
+	add(http.MethodPost, "/variants/:variantName/redemptions", op(d, "Access", "Redeem an access code or invitation").auth().
+		body(entities.AccessRedeem{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/variants/:variantName/live", op(d, "Live", "Open a live session and get its PIN").auth().
+		body(entities.LiveOpen{}).ok(http.StatusCreated, entities.LiveSession{}).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/variants/:variantName/proctor/events", op(d, "Proctor", "Server-Sent Events of attempts in progress").auth().
+		content(http.StatusOK, "text/event-stream", d.Schema(entities.ProctorEvent{})).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/variants/:variantName/access", op(d, "Access", "Create an access code or invitation").auth().
+		body(entities.Access{}).ok(http.StatusCreated, entities.Access{}).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/variants/:variantName/access", op(d, "Access", "List access codes").auth().
+		ok(http.StatusOK, []*entities.Access{}).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/variants/:variantName/access/:accessId", op(d, "Access", "Remove an access code").auth().
+		ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+
+	add(http.MethodPost, "/variants/:variantName/practice", op(d, "Practice", "Start a practice attempt").auth().
+		ok(http.StatusOK, practiceStart{}).fails(401, 403, 404).build())
+	add(http.MethodGet, "/variants/:variantName/practice", op(d, "Practice", "Results of the latest practice attempt").auth().
+		ok(http.StatusOK, entities.Practice{}).fails(401, 404).build())
+	add(http.MethodPost, "/variants/:variantName/practice/results", op(d, "Practice", "Finish the practice attempt").auth().
+		ok(http.StatusOK, entities.Practice{}).fails(401, 404).build())
+	add(http.MethodPost, "/variants/:variantName/practice/questions/:questionId/answers", op(d, "Practice", "Check a practice answer").auth().
+		body(entities.UserAnswer{}).ok(http.StatusOK, entities.PracticeFeedback{}).fails(400, 401, 403, 404).build())
+
+	add(http.MethodPost, "/variants/:variantName/questions", op(d, "Questions", "Add a question").auth().
+		body(entities.Question{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404, 409).build())
+	add(http.MethodGet, "/variants/:variantName/questions/:questionId", op(d, "Questions", "Get a question").auth().
+		ok(http.StatusOK, entities.Question{}).fails(400, 401, 403, 404).build())
+	add(http.MethodDelete, "/variants/:variantName/questions/:questionId", op(d, "Questions", "Remove a question").auth().
+		ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/variants/:variantName/questions/:questionId/answers", op(d, "Testing", "Answer a question").auth().
+		body(entities.UserAnswer{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404, 409).build())
+	add(http.MethodPost, "/variants/:variantName/questions/:questionId/hints", op(d, "Testing", "Reveal the next hint").auth().
+		ok(http.StatusOK, entities.Hint{}).fails(400, 401, 403, 404, 409).build())
+}
+
+// legacy documents the routes that predate /api/v1, all deprecated.
+func legacy(d *openapi.Document) {
+	add := func(method, path string, operation *openapi.Operation) {
+		operation.Deprecated = true
+		d.Add(method, path, operation)
+	}
+
+	add(http.MethodPost, "/register", op(d, "Auth", "Register a user by invite code or in the default organization").
+		body(entities.Register{}).ok(http.StatusCreated, entities.User{}).fails(400, 404, 409).build())
+	add(http.MethodPost, "/login", op(d, "Auth", "Log in").
+		body(entities.Login{}).ok(http.StatusCreated, entities.User{}).fails(400, 401).build())
+	add(http.MethodGet, "/certificate/:code", op(d, "Certificates", "Verify a certificate by its code").
+		ok(http.StatusOK, entities.Certificate{}).fails(404).build())
+
+	add(http.MethodPost, "/:userId/quit", op(d, "Auth", "Log out").
+		ok(http.StatusOK, nil).fails(401, 404).build())
+	add(http.MethodPost, "/:userId/graphql", op(d, "GraphQL", "Query variants, questions and attempts of the organization").
+		body(entities.GraphQuery{}).content(http.StatusOK, "application/json", d.Schema(entities.GraphResult{})).fails(400, 401).build())
+	add(http.MethodPost, "/:userId/organization/add", op(d, "Organizations", "Create an organization and its invite code").
+		body(entities.Organization{}).ok(http.StatusCreated, entities.Organization{}).fails(400, 401, 403, 409).build())
+
+	add(http.MethodPost, "/:userId/group/add", op(d, "Groups", "Create a group").
+		body(entities.Group{}).ok(http.StatusCreated, entities.Group{}).fails(400, 401, 409).build())
+	add(http.MethodGet, "/:userId/group/list", op(d, "Groups", "List own groups").
+		ok(http.StatusOK, []*entities.Group{}).fails(401).build())
+	add(http.MethodGet, "/:userId/group/:groupId/get", op(d, "Groups", "Group with members and assignments").
+		ok(http.StatusOK, entities.Group{}).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/:userId/group/:groupId/remove", op(d, "Groups", "Remove a group").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodPost, "/:userId/group/:groupId/member/add", op(d, "Groups", "Add members by login").
+		body(entities.GroupMembers{}).ok(http.StatusOK, entities.GroupMembersResult{}).fails(400, 401, 403, 404).build())
+	add(http.MethodDelete, "/:userId/group/:groupId/member/remove", op(d, "Groups", "Remove members by login").
+		body(entities.GroupMembers{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/:userId/group/:groupId/assign", op(d, "Groups", "Assign a variant to the group").
+		body(entities.GroupAssignment{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/:userId/group/:groupId/progress", op(d, "Groups", "Progress of every member on every assignment").
+		ok(http.StatusOK, []*entities.GroupProgress{}).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/:userId/webhook/add", op(d, "Webhooks", "Subscribe a url to organization events").
+		body(entities.Webhook{}).ok(http.StatusCreated, entities.Webhook{}).fails(400, 401, 403).build())
+	add(http.MethodGet, "/:userId/webhook/list", op(d, "Webhooks", "List the organization's webhooks").
+		ok(http.StatusOK, []*entities.Webhook{}).fails(401, 403).build())
+	add(http.MethodDelete, "/:userId/webhook/:webhookId/remove", op(d, "Webhooks", "Remove a webhook").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodGet, "/:userId/webhook/:webhookId/deliveries", op(d, "Webhooks", "Latest deliveries of a webhook").
+		ok(http.StatusOK, []*entities.WebhookDelivery{}).fails(401, 403, 404).build())
+
+	add(http.MethodGet, "/:userId/live/:pin/ws", op(d, "Live", "Join a live session over WebSocket").
+		describe(http.StatusSwitchingProtocols, "WebSocket of LiveMessage frames; participants send LiveAnswer frames").
+		schema(entities.LiveMessage{}).schema(entities.LiveAnswer{}).fails(401, 403, 404).build())
+	add(http.MethodPost, "/:userId/live/:pin/next", op(d, "Live", "Close the open question or open the next one").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodPost, "/:userId/live/:pin/finish", op(d, "Live", "Finish the live session").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/:userId/variant/add", op(d, "Variants", "Create a variant").
+		body(entities.Variant{}).ok(http.StatusCreated, nil).fails(400, 401, 409).build())
+	add(http.MethodGet, "/:userId/variant/list", op(d, "Variants", "List the organization's variants").
+		ok(http.StatusOK, []*entities.Variant{}).fails(401, 404).build())
+
+	add(http.MethodGet, "/:userId/variant/:variantName/get", op(d, "Variants", "Variant with its questions").
+		ok(http.StatusOK, entities.Variant{}).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/:userId/variant/:variantName/remove", op(d, "Variants", "Remove a variant").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+	add(http.MethodPut, "/:userId/variant/:variantName/settings", op(d, "Variants", "Change variant settings").
+		body(entities.VariantSettings{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/start", op(d, "Testing", "Start an attempt").
+		ok(http.StatusOK, entities.Variant{}).fails(401, 403, 404, 409).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/results", page(d, "Testing", "Finish the attempt and show the results page"))
+	add(http.MethodGet, "/:userId/variant/:variantName/review", op(d, "Testing", "Review a finished attempt").
+		ok(http.StatusOK, []*entities.Review{}).fails(401, 404, 409).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/certificate", op(d, "Certificates", "Download the PDF certificate").
+		content(http.StatusOK, "application/pdf", &openapi.Schema{Type: "string", Format: "binary"}).fails(401, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/redeem", op(d, "Access", "Redeem an access code or invitation").
+		body(entities.AccessRedeem{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/live/open", op(d, "Live", "Open a live session and get its PIN").
+		body(entities.LiveOpen{}).ok(http.StatusCreated, entities.LiveSession{}).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/proctor/events", op(d, "Proctor", "Server-Sent Events of attempts in progress").
+		content(http.StatusOK, "text/event-stream", d.Schema(entities.ProctorEvent{})).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/:userId/variant/:variantName/access/add", op(d, "Access", "Create an access code or invitation").
+		body(entities.Access{}).ok(http.StatusCreated, entities.Access{}).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/access/list", op(d, "Access", "List access codes").
+		ok(http.StatusOK, []*entities.Access{}).fails(401, 403, 404).build())
+	add(http.MethodDelete, "/:userId/variant/:variantName/access/:accessId/remove", op(d, "Access", "Remove an access code").
+		ok(http.StatusOK, nil).fails(401, 403, 404).build())
+
+	add(http.MethodPost, "/:userId/variant/:variantName/practice/start", op(d, "Practice", "Start a practice attempt").
+		ok(http.StatusOK, practiceStart{}).fails(401, 403, 404).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/practice/results", op(d, "Practice", "Results of the latest practice attempt").
+		ok(http.StatusOK, entities.Practice{}).fails(401, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/practice/finish", op(d, "Practice", "Finish the practice attempt").
+		ok(http.StatusOK, entities.Practice{}).fails(401, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/practice/question/:questionId/accept", op(d, "Practice", "Check a practice answer").
+		body(entities.UserAnswer{}).ok(http.StatusOK, entities.PracticeFeedback{}).fails(400, 401, 403, 404).build())
+
+	add(http.MethodPost, "/:userId/variant/:variantName/question/add", op(d, "Questions", "Add a question").
+		body(entities.Question{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404, 409).build())
+	add(http.MethodDelete, "/:userId/variant/:variantName/question/remove", op(d, "Questions", "Remove a question").
+		body(entities.QuestionRemove{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404).build())
+	add(http.MethodGet, "/:userId/variant/:variantName/question/:questionId/get", op(d, "Questions", "Get a question").
+		ok(http.StatusOK, entities.Question{}).fails(401, 403, 404).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/question/:questionId/accept", op(d, "Testing", "Answer a question").
+		body(entities.UserAnswer{}).ok(http.StatusOK, nil).fails(400, 401, 403, 404, 409).build())
+	add(http.MethodPost, "/:userId/variant/:variantName/question/:questionId/hint", op(d, "Testing", "Reveal the next hint").
+		ok(http.StatusOK, entities.Hint{}).fails(401, 403, 404, 409).build())
+}
+
+type operation struct {
+	doc *openapi.Document
+	op  *openapi.Operation
+}
+
+func op(doc *openapi.Document, tag, summary string) *operation {
+	return &operation{
+		doc: doc,
+		op:  &openapi.Operation{Summary: summary, Tags: []string{tag}, Responses: make(map[string]*openapi.Response)},
+	}
+}
+
+func page(doc *openapi.Document, tag, summary string) *openapi.Operation {
+	return op(doc, tag, summary).content(http.StatusOK, "text/html", openapi.String()).build()
+}
+
+// auth marks the operation as taking the user from the bearer token.
+func (o *operation) auth() *operation {
+	o.op.Security = append(o.op.Security, openapi.SecurityRequirement{bearer: {}})
+	return o
+}
+
+func (o *operation) body(v any) *operation {
+	o.op.RequestBody = &openapi.RequestBody{Required: true, Content: openapi.JSON(o.doc.Schema(v))}
+	return o
+}
+
+// ok is a successful handlers.Response carrying data; nil means no data.
+func (o *operation) ok(status int, data any) *operation {
+	properties := map[string]*openapi.Schema{
+		"status":  openapi.Integer(),
+		"message": openapi.String(),
+	}
+	if data != nil {
+		properties["data"] = o.doc.Schema(data)
+	}
+	o.op.Responses[strconv.Itoa(status)] = &openapi.Response{
+		Description: http.StatusText(status),
+		Content:     openapi.JSON(openapi.Object(properties)),
+	}
+	return o
+}
+
+func (o *operation) content(status int, mediaType string, schema *openapi.Schema) *operation {
+	o.op.Responses[strconv.Itoa(status)] = &openapi.Response{
+		Description: http.StatusText(status),
+		Content:     map[string]*openapi.MediaType{mediaType: {Schema: schema}},
+	}
+	return o
+}
+
+func (o *operation) describe(status int, description string) *operation {
+	o.op.Responses[strconv.Itoa(status)] = &openapi.Response{Description: description}
+	return o
+}
+
+// schema registers a type that travels outside of request and response bodies,
+// such as WebSocket frames.
+func (o *operation) schema(v any) *operation {
+	o.doc.Schema(v)
+	return o
+}
+
+// fails documents error responses, which carry status and message only.
+func (o *operation) fails(statuses ...int) *operation {
+	for _, status := range statuses {
+		o.op.Responses[strconv.Itoa(status)] = &openapi.Response{
+			Description: http.StatusText(status),
+			Content:     openapi.JSON(o.doc.Schema(handlers.Response{})),
+		}
+	}
+	return o
+}
+
+func (o *operation) build() *openapi.Operation {
+	return o.op
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"github.com/graph-gophers/dataloader/v7"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+)
+
+type loadersKey struct{}
+
+// loaders collect the keys requested by sibling resolvers and fetch them with a
+// single query per type. They cache for the lifetime of one request only.
+type loaders struct {
+	user *entities.User
+
+	variants  *dataloader.Loader[int, *entities.Variant]
+	questions *dataloader.Loader[int, []*entities.Question]
+	answers   *dataloader.Loader[int, []*entities.Answer]
+	attempts  *dataloader.Loader[int, []*entities.Testing]
+	users     *dataloader.Loader[int, *entities.User]
+}
+
+// WithLoaders attaches fresh loaders scoped to the user's organization.
+func WithLoaders(ctx context.Context, service *service.Service, user *entities.User) context.Context {
+	tenantId := user.OrganizationId
+
+	l := &loaders{
+		user: user,
+		variants: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[*entities.Variant] {
+			variants, err := service.GraphService.GraphVariantsByIds(ctx, tenantId, ids)
+			return one(ids, variants, err, func(v *entities.Variant) int { return v.Id })
+		}),
+		questions: dataloader.NewBatchedLoader(func(ctx context.Context, variantIds []int) []*dataloader.Result[[]*entities.Question] {
+			questions, err := service.GraphService.GraphQuestions(ctx, tenantId, variantIds)
+			return many(variantIds, questions, err, func(q *entities.Question) int { return q.VariantId })
+		}),
+		answers: dataloader.NewBatchedLoader(func(ctx context.Context, questionIds []int) []*dataloader.Result[[]*entities.Answer] {
+			answers, err := service.GraphService.GraphAnswers(ctx, tenantId, questionIds)
+			return many(questionIds, answers, err, func(a *entities.Answer) int { return a.QuestionId })
+		}),
+		attempts: dataloader.NewBatchedLoader(func(ctx context.Context, userIds []int) []*dataloader.Result[[]*entities.Testing] {
+			attempts, err := service.GraphService.GraphAttempts(ctx, tenantId, userIds)
+			return many(userIds, attempts, err, func(t *entities.Testing) int { return t.UserId })
+		}),
+		users: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[*entities.User] {
+			users, err := service.GraphService.GraphUsers(ctx, tenantId, ids)
+			return one(ids, users, err, func(u *entities.User) int { return u.ID })
+		}),
+	}
+
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// one lines rows up with the keys they were loaded by; a key without a row
+// resolves to nil. The loader expects exactly one result per key, in order.
+func one[V any](keys []int, rows []V, err error, key func(V) int) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], len(keys))
+
+	byKey := make(map[int]V, len(rows))
+	for _, row := range rows {
+		byKey[key(row)] = row
+	}
+	for i, k := range keys {
+		results[i] = &dataloader.Result[V]{Data: byKey[k], Error: err}
+	}
+
+	return results
+}
+
+// many groups rows under the key they belong to, keeping the query's order.
+func many[V any](keys []int, rows []V, err error, key func(V) int) []*dataloader.Result[[]V] {
+	results := make([]*dataloader.Result[[]V], len(keys))
+
+	byKey := make(map[int][]V, len(keys))
+	for _, row := range rows {
+		byKey[key(row)] = append(byKey[key(row)], row)
+	}
+	for i, k := range keys {
+		results[i] = &dataloader.Result[[]V]{Data: byKey[k], Error: err}
+	}
+
+	return results
+}
@@ -0,0 +1,299 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/graph-gophers/graphql-go"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/service"
+	"quiz-service/pkg/constants"
+	"sort"
+	"time"
+)
+
+// maxDepth bounds how far a query may follow attempt -> variant -> questions.
+const maxDepth = 8
+
+//go:embed schema.graphql
+var schema string
+
+// NewSchema parses schema.graphql against the resolvers. A mismatch between the
+// two is a programming error, so it panics at startup.
+func NewSchema(service *service.Service) *graphql.Schema {
+	return graphql.MustParseSchema(schema, &Resolver{service: service}, graphql.MaxDepth(maxDepth))
+}
+
+type Resolver struct {
+	service *service.Service
+}
+
+func (r *Resolver) Me(ctx context.Context) *userResolver {
+	return &userResolver{user: loadersFrom(ctx).user}
+}
+
+func (r *Resolver) Variants(ctx context.Context) ([]*variantResolver, error) {
+	l := loadersFrom(ctx)
+
+	variants, err := r.service.GraphService.GraphVariants(ctx, l.user.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err = r.service.VariantService.VariantAllowed(ctx, variants, l.user)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*variantResolver, 0, len(variants))
+	for _, variant := range variants {
+		l.variants.Prime(ctx, variant.Id, variant)
+		resolvers = append(resolvers, &variantResolver{variant: variant})
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) Variant(ctx context.Context, args struct{ Name string }) (*variantResolver, error) {
+	l := loadersFrom(ctx)
+
+	variant, err := r.service.VariantService.VariantGet(ctx, l.user.OrganizationId, args.Name)
+	if err != nil {
+		if errors.Is(err, constants.ErrorVariantNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.service.VariantService.VariantAccess(ctx, variant, l.user); err != nil {
+		return nil, err
+	}
+	l.variants.Prime(ctx, variant.Id, variant)
+
+	return &variantResolver{variant: variant}, nil
+}
+
+type userResolver struct {
+	user *entities.User
+}
+
+func (u *userResolver) Id() int32 {
+	return int32(u.user.ID)
+}
+
+func (u *userResolver) Login() string {
+	return u.user.Login
+}
+
+func (u *userResolver) Attempts(ctx context.Context) ([]*attemptResolver, error) {
+	attempts, err := loadersFrom(ctx).attempts.Load(ctx, u.user.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*attemptResolver, 0, len(attempts))
+	for _, attempt := range attempts {
+		resolvers = append(resolvers, &attemptResolver{attempt: attempt})
+	}
+
+	return resolvers, nil
+}
+
+type variantResolver struct {
+	variant *entities.Variant
+}
+
+func (v *variantResolver) Id() int32 {
+	return int32(v.variant.Id)
+}
+
+func (v *variantResolver) Name() string {
+	return v.variant.Name
+}
+
+func (v *variantResolver) Status() string {
+	return v.variant.Status
+}
+
+func (v *variantResolver) PassMark() float64 {
+	return v.variant.PassMark
+}
+
+func (v *variantResolver) RevealAnswers() bool {
+	return v.variant.RevealAnswers
+}
+
+func (v *variantResolver) RevealExplanations() bool {
+	return v.variant.RevealExplanations
+}
+
+func (v *variantResolver) Restricted() bool {
+	return v.variant.Restricted
+}
+
+func (v *variantResolver) OpensAt() *string {
+	return timeString(v.variant.OpensAt)
+}
+
+func (v *variantResolver) ClosesAt() *string {
+	return timeString(v.variant.ClosesAt)
+}
+
+func (v *variantResolver) Questions(ctx context.Context) ([]*questionResolver, error) {
+	questions, err := loadersFrom(ctx).questions.Load(ctx, v.variant.Id)()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*questionResolver, 0, len(questions))
+	for _, question := range questions {
+		resolvers = append(resolvers, &questionResolver{question: question})
+	}
+
+	return resolvers, nil
+}
+
+func (v *variantResolver) Attempt(ctx context.Context) (*attemptResolver, error) {
+	l := loadersFrom(ctx)
+
+	attempts, err := l.attempts.Load(ctx, l.user.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attempt := range attempts {
+		if attempt.VariantId == v.variant.Id {
+			return &attemptResolver{attempt: attempt}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+type questionResolver struct {
+	question *entities.Question
+}
+
+func (q *questionResolver) Id() int32 {
+	return int32(q.question.Id)
+}
+
+func (q *questionResolver) Question() string {
+	return q.question.Question
+}
+
+func (q *questionResolver) Points() float64 {
+	return *q.question.Points
+}
+
+func (q *questionResolver) Multiple() bool {
+	return q.question.Multiple
+}
+
+// Options merges the correct answer with the other choices and sorts them, so
+// the position of an option says nothing about whether it is correct.
+func (q *questionResolver) Options(ctx context.Context) ([]*optionResolver, error) {
+	answers, err := loadersFrom(ctx).answers.Load(ctx, q.question.Id)()
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]string, 0, len(answers)+1)
+	options = append(options, q.question.Answer)
+	for _, answer := range answers {
+		options = append(options, answer.Answer)
+	}
+	sort.Strings(options)
+
+	resolvers := make([]*optionResolver, 0, len(options))
+	for _, option := range options {
+		resolvers = append(resolvers, &optionResolver{answer: option})
+	}
+
+	return resolvers, nil
+}
+
+type optionResolver struct {
+	answer string
+}
+
+func (o *optionResolver) Answer() string {
+	return o.answer
+}
+
+type attemptResolver struct {
+	attempt *entities.Testing
+}
+
+func (a *attemptResolver) Id() int32 {
+	return int32(a.attempt.ID)
+}
+
+func (a *attemptResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := loadersFrom(ctx).users.Load(ctx, a.attempt.UserId)()
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, constants.ErrorUserNotFound
+	}
+	return &userResolver{user: user}, nil
+}
+
+func (a *attemptResolver) Variant(ctx context.Context) (*variantResolver, error) {
+	variant, err := loadersFrom(ctx).variants.Load(ctx, a.attempt.VariantId)()
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil {
+		return nil, constants.ErrorVariantNotFound
+	}
+	return &variantResolver{variant: variant}, nil
+}
+
+func (a *attemptResolver) CorrectAnswers() int32 {
+	return int32(a.attempt.CorrectAnswers)
+}
+
+func (a *attemptResolver) Penalty() float64 {
+	return a.attempt.Penalty
+}
+
+func (a *attemptResolver) Score() float64 {
+	return a.attempt.Score
+}
+
+func (a *attemptResolver) MaxScore() float64 {
+	return a.attempt.MaxScore
+}
+
+// Passed applies the variant's pass mark the same way results do; an
+// unfinished attempt has not passed.
+func (a *attemptResolver) Passed(ctx context.Context) (bool, error) {
+	if a.attempt.FinishAt == nil {
+		return false, nil
+	}
+
+	variant, err := a.Variant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	pass := variant.variant.PassMark
+	return pass > 0 && a.attempt.MaxScore > 0 && a.attempt.Score*100/a.attempt.MaxScore >= pass, nil
+}
+
+func (a *attemptResolver) StartAt() string {
+	return a.attempt.StartAt.Format(time.RFC3339)
+}
+
+func (a *attemptResolver) FinishAt() *string {
+	return timeString(a.attempt.FinishAt)
+}
+
+func timeString(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+	"strconv"
+)
+
+func (h *Handler) AccessAdd(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("AccessAdd handler received by: %s", ctx.Request.UserAgent())
+
+	accessEntity := new(entities.Access)
+	if err := ctx.ShouldBindBodyWithJSON(accessEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	access, err := h.service.AccessService.AccessAdd(ctx.Request.Context(), variant.OrganizationId, variant.Id, accessEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusCreated, "Access code successfully created", access)
+	return
+}
+
+func (h *Handler) AccessList(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("AccessList handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	accesses, err := h.service.AccessService.AccessList(ctx.Request.Context(), variant.OrganizationId, variant.Id)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "access codes", accesses)
+	return
+}
+
+func (h *Handler) AccessRemove(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("AccessRemove handler received by: %s", ctx.Request.UserAgent())
+
+	accessId, _ := strconv.Atoi(ctx.Param("accessId"))
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	if err := h.service.AccessService.AccessRemove(ctx.Request.Context(), variant.OrganizationId, variant.Id, accessId); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Access code successfully removed", nil)
+	return
+}
+
+func (h *Handler) AccessRedeem(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("AccessRedeem handler received by: %s", ctx.Request.UserAgent())
+
+	redeemEntity := new(entities.AccessRedeem)
+	if err := ctx.ShouldBindBodyWithJSON(redeemEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.AccessService.AccessRedeem(ctx.Request.Context(), variant.OrganizationId, variant.Id, user.ID, redeemEntity.Code); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Access granted", nil)
+	return
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+)
+
+func (h *Handler) CertificateDownload(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("CertificateDownload handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	cert, pdf, err := h.service.CertificateService.CertificateDownload(ctx.Request.Context(), variant.OrganizationId, variant.Id, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="certificate-%s.pdf"`, cert.Code))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+	return
+}
+
+func (h *Handler) CertificateVerify(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("CertificateVerify handler received by: %s", ctx.Request.UserAgent())
+
+	cert, err := h.service.CertificateService.CertificateVerify(ctx.Request.Context(), ctx.Param("code"))
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "certificate is valid", cert)
+	return
+}
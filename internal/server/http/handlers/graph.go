@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/server/http/graph"
+)
+
+// GraphQL answers in the GraphQL response format rather than Response: the
+// status is 200 whenever the query was executed, errors travel in the body.
+func (h *Handler) GraphQL(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("graphql handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	queryEntity := new(entities.GraphQuery)
+	if err := ctx.ShouldBindBodyWithJSON(queryEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	request := graph.WithLoaders(ctx.Request.Context(), h.service, user)
+	response := h.graph.Exec(request, queryEntity.Query, queryEntity.OperationName, queryEntity.Variables)
+
+	ctx.JSON(http.StatusOK, response)
+	return
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+	"strconv"
+)
+
+func (h *Handler) GroupAdd(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupAdd handler received by: %s", ctx.Request.UserAgent())
+
+	groupEntity := new(entities.Group)
+	if err := ctx.ShouldBindBodyWithJSON(groupEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	group, err := h.service.GroupsService.GroupAdd(ctx.Request.Context(), user.OrganizationId, user.ID, groupEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusCreated, "Group successfully created", group)
+	return
+}
+
+func (h *Handler) GroupList(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupList handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	groups, err := h.service.GroupsService.GroupList(ctx.Request.Context(), user.OrganizationId, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "all groups", groups)
+	return
+}
+
+func (h *Handler) GroupCheck(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupCheck handler received by: %s", ctx.Request.UserAgent())
+
+	groupId, _ := strconv.Atoi(ctx.Param("groupId"))
+	user := ctx.MustGet("user").(*entities.User)
+
+	group, err := h.service.GroupsService.GroupGet(ctx.Request.Context(), user.OrganizationId, groupId, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Set("group", group)
+	ctx.Next()
+}
+
+func (h *Handler) GroupGet(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupGet handler received by: %s", ctx.Request.UserAgent())
+
+	NewSuccessResponse(ctx, http.StatusOK, "group", ctx.MustGet("group").(*entities.Group))
+	return
+}
+
+func (h *Handler) GroupRemove(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupRemove handler received by: %s", ctx.Request.UserAgent())
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.GroupsService.GroupRemove(ctx.Request.Context(), user.OrganizationId, group.Id); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Group successfully removed", nil)
+	return
+}
+
+func (h *Handler) GroupMembersAdd(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupMembersAdd handler received by: %s", ctx.Request.UserAgent())
+
+	membersEntity := new(entities.GroupMembers)
+	if err := ctx.ShouldBindBodyWithJSON(membersEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	result, err := h.service.GroupsService.GroupMembersAdd(ctx.Request.Context(), user.OrganizationId, group.Id, membersEntity.Logins)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Members successfully added", result)
+	return
+}
+
+func (h *Handler) GroupMembersRemove(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupMembersRemove handler received by: %s", ctx.Request.UserAgent())
+
+	membersEntity := new(entities.GroupMembers)
+	if err := ctx.ShouldBindBodyWithJSON(membersEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.GroupsService.GroupMembersRemove(ctx.Request.Context(), user.OrganizationId, group.Id, membersEntity.Logins); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Members successfully removed", nil)
+	return
+}
+
+// GroupMemberRemove removes the single member named in the path, so the
+// DELETE carries no body.
+func (h *Handler) GroupMemberRemove(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupMemberRemove handler received by: %s", ctx.Request.UserAgent())
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.GroupsService.GroupMembersRemove(ctx.Request.Context(), user.OrganizationId, group.Id, []string{ctx.Param("login")}); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Member successfully removed", nil)
+	return
+}
+
+func (h *Handler) GroupAssign(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupAssign handler received by: %s", ctx.Request.UserAgent())
+
+	assignmentEntity := new(entities.GroupAssignment)
+	if err := ctx.ShouldBindBodyWithJSON(assignmentEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.GroupsService.GroupAssign(ctx.Request.Context(), group.Id, user, assignmentEntity); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Variant successfully assigned", nil)
+	return
+}
+
+func (h *Handler) GroupProgress(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("GroupProgress handler received by: %s", ctx.Request.UserAgent())
+
+	group := ctx.MustGet("group").(*entities.Group)
+	user := ctx.MustGet("user").(*entities.User)
+
+	progress, err := h.service.GroupsService.GroupProgress(ctx.Request.Context(), user.OrganizationId, group.Id)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "group progress", progress)
+	return
+}
@@ -3,39 +3,49 @@ package handlers
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
 	"net/http"
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
+	"quiz-service/internal/server/http/graph"
 	"quiz-service/internal/service"
 	"quiz-service/pkg/constants"
+	"sync"
 )
 
 type Handler struct {
 	service *service.Service
+	graph   *graphql.Schema
+
+	// closing is closed on shutdown to end the streams, which would otherwise
+	// keep the server waiting for them
+	closing chan struct{}
+	close   sync.Once
 
 	logger logger.Logging
 }
 
 func NewHandler(service *service.Service, logger logger.Logging) *Handler {
-	return &Handler{service: service, logger: logger}
+	return &Handler{service: service, graph: graph.NewSchema(service), closing: make(chan struct{}), logger: logger}
+}
+
+// CloseStreams ends every open event stream.
+func (h *Handler) CloseStreams() {
+	h.close.Do(func() { close(h.closing) })
 }
 
 func (h *Handler) Register(ctx *gin.Context) {
-	h.logger.InfoF("register handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("register handler received by: %s", ctx.Request.UserAgent())
 
 	registerEntity := new(entities.Register)
 	if err := ctx.ShouldBindBodyWithJSON(registerEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
 	user, err := h.service.RegisterService.Register(ctx.Request.Context(), registerEntity)
 	if err != nil {
-		if errors.Is(err, constants.ErrorUserAlreadyExists) {
-			NewErrorResponse(ctx, http.StatusConflict, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -44,21 +54,21 @@ func (h *Handler) Register(ctx *gin.Context) {
 }
 
 func (h *Handler) Login(ctx *gin.Context) {
-	h.logger.InfoF("login handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("login handler received by: %s", ctx.Request.UserAgent())
 
 	loginEntity := new(entities.Login)
 	if err := ctx.ShouldBindBodyWithJSON(loginEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
 	user, err := h.service.RegisterService.Login(ctx.Request.Context(), loginEntity)
 	if err != nil {
 		if errors.Is(err, constants.ErrorUserNotFound) {
-			NewErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+			NewErrorStatusResponse(ctx, http.StatusUnauthorized, err)
 			return
 		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// The probe handlers are hit every few seconds by the orchestrator and do not
+// log each call.
+
+func (h *Handler) Healthz(ctx *gin.Context) {
+	NewSuccessResponse(ctx, http.StatusOK, "alive", nil)
+}
+
+func (h *Handler) Readyz(ctx *gin.Context) {
+	if err := h.service.HealthService.Ready(ctx.Request.Context()); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "ready", nil)
+}
+
+func (h *Handler) Version(ctx *gin.Context) {
+	version, err := h.service.HealthService.Version(ctx.Request.Context())
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "", version)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"io"
+	"net/http"
+	"quiz-service/internal/entities"
+	"sync"
+	"time"
+)
+
+const (
+	liveWriteWait  = 10 * time.Second
+	livePongWait   = 60 * time.Second
+	livePingPeriod = livePongWait * 9 / 10
+	liveReadLimit  = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+func (h *Handler) LiveOpen(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("LiveOpen handler received by: %s", ctx.Request.UserAgent())
+
+	openEntity := new(entities.LiveOpen)
+	if err := ctx.ShouldBindBodyWithJSON(openEntity); err != nil && !errors.Is(err, io.EOF) {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	session, err := h.service.LiveService.LiveOpen(ctx.Request.Context(), variant, user, openEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusCreated, "Live session successfully opened", session)
+	return
+}
+
+func (h *Handler) LiveNext(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("LiveNext handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.LiveService.LiveNext(ctx.Request.Context(), ctx.Param("pin"), user.ID); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Live session moved forward", nil)
+	return
+}
+
+func (h *Handler) LiveFinish(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("LiveFinish handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.LiveService.LiveFinish(ctx.Request.Context(), ctx.Param("pin"), user.ID); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Live session finished", nil)
+	return
+}
+
+// LiveConnect joins the live session and upgrades the request to a websocket.
+// The session pushes its messages to the client, the client sends back answers.
+func (h *Handler) LiveConnect(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("LiveConnect handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	client, err := h.service.LiveService.LiveJoin(ctx.Request.Context(), ctx.Param("pin"), user)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+	defer h.service.LiveService.LiveLeave(client)
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		h.logger.WithContext(ctx.Request.Context()).ErrorF("LiveConnect upgrade failed: %v", err)
+		return
+	}
+
+	var mu sync.Mutex
+	write := func(message *entities.LiveMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+		if message == nil {
+			return conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		return conn.WriteJSON(message)
+	}
+
+	go func() {
+		ticker := time.NewTicker(livePingPeriod)
+		defer func() {
+			ticker.Stop()
+			conn.Close()
+		}()
+
+		for {
+			select {
+			case message, ok := <-client.Messages:
+				if !ok {
+					mu.Lock()
+					conn.WriteControl(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(liveWriteWait))
+					mu.Unlock()
+					return
+				}
+				if err := write(message); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if err := write(nil); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	conn.SetReadLimit(liveReadLimit)
+	conn.SetReadDeadline(time.Now().Add(livePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		answerEntity := new(entities.LiveAnswer)
+		if err := json.Unmarshal(payload, answerEntity); err != nil || answerEntity.QuestionId == 0 {
+			write(&entities.LiveMessage{Type: entities.LiveMessageError, Data: "Invalid message"})
+			continue
+		}
+
+		result, err := h.service.LiveService.LiveAnswer(ctx.Request.Context(), client, answerEntity)
+		if err != nil {
+			write(&entities.LiveMessage{Type: entities.LiveMessageError, Data: err.Error()})
+			continue
+		}
+
+		write(&entities.LiveMessage{Type: entities.LiveMessageAnswered, Data: result})
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+)
+
+func (h *Handler) OrganizationAdd(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("OrganizationAdd handler received by: %s", ctx.Request.UserAgent())
+
+	organizationEntity := new(entities.Organization)
+	if err := ctx.ShouldBindBodyWithJSON(organizationEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	organization, err := h.service.OrganizationsService.OrganizationAdd(ctx.Request.Context(), organizationEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusCreated, "Organization successfully created", organization)
+	return
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+	"strconv"
+)
+
+func (h *Handler) PracticeStart(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("PracticeStart handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	practice, err := h.service.PracticeService.PracticeStart(ctx.Request.Context(), variant, user)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "practice successfully started", gin.H{
+		"practice": practice,
+		"variant":  h.service.VariantService.VariantView(variant, user),
+	})
+	return
+}
+
+func (h *Handler) PracticeAccept(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("PracticeAccept handler received by: %s", ctx.Request.UserAgent())
+
+	answerEntity := new(entities.UserAnswer)
+	if err := ctx.ShouldBindBodyWithJSON(answerEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	questionId, _ := strconv.Atoi(ctx.Param("questionId"))
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	feedback, err := h.service.PracticeService.PracticeAccept(ctx.Request.Context(), variant, user, questionId, answerEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "answer checked", feedback)
+	return
+}
+
+func (h *Handler) PracticeFinish(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("PracticeFinish handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	practice, err := h.service.PracticeService.PracticeFinish(ctx.Request.Context(), variant.OrganizationId, variant.Id, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "practice finished", practice)
+	return
+}
+
+func (h *Handler) PracticeResults(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("PracticeResults handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	practice, err := h.service.PracticeService.PracticeResults(ctx.Request.Context(), variant.OrganizationId, variant.Id, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "practice results", practice)
+	return
+}
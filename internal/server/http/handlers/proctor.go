@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"quiz-service/internal/entities"
+	"strconv"
+	"time"
+)
+
+const (
+	proctorRetry     = 3 * time.Second
+	proctorKeepAlive = 5 * time.Second
+)
+
+// ProctorEvents streams the attempts of the variant as Server-Sent Events.
+// A reconnecting EventSource sends Last-Event-ID and gets the events it missed first.
+// The stream lifts the server's write timeout and ends on shutdown.
+func (h *Handler) ProctorEvents(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("ProctorEvents handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	lastEventId, _ := strconv.ParseInt(ctx.GetHeader("Last-Event-ID"), 10, 64)
+
+	backlog, events, cancel := h.service.ProctorService.ProctorSubscribe(variant.Id, lastEventId)
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithContext(ctx.Request.Context()).ErrorF("ProctorEvents write deadline: %v", err)
+	}
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", proctorRetry.Milliseconds())
+	for _, event := range backlog {
+		writeProctorEvent(ctx.Writer, event)
+	}
+	ctx.Writer.Flush()
+
+	ticker := time.NewTicker(proctorKeepAlive)
+	defer ticker.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-h.closing:
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			writeProctorEvent(w, event)
+			return true
+		case <-ticker.C:
+			io.WriteString(w, ": keep-alive\n\n")
+			return true
+		}
+	})
+}
+
+func writeProctorEvent(w io.Writer, event *entities.ProctorEvent) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+}
@@ -1,39 +1,25 @@
 package handlers
 
 import (
-	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"quiz-service/internal/entities"
-	"quiz-service/pkg/constants"
 	"strconv"
 )
 
 func (h *Handler) QuestionAdd(ctx *gin.Context) {
-	h.logger.InfoF("QuestionAdd handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionAdd handler received by: %s", ctx.Request.UserAgent())
 
 	questionEntity := new(entities.Question)
 	if err := ctx.ShouldBindBodyWithJSON(questionEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
 	variant := ctx.MustGet("variant").(*entities.Variant)
 
-	if err := h.service.QuestionsService.QuestionAdd(ctx.Request.Context(), variant.Id, questionEntity); err != nil {
-		if errors.Is(err, constants.ErrorVariantNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, constants.ErrorQuestionLimitExceeded) {
-			NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
-			return
-		}
-		if errors.Is(err, constants.ErrorQuestionAlreadyExists) {
-			NewErrorResponse(ctx, http.StatusConflict, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.service.QuestionsService.QuestionAdd(ctx.Request.Context(), variant.OrganizationId, variant.Id, questionEntity); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -42,22 +28,42 @@ func (h *Handler) QuestionAdd(ctx *gin.Context) {
 }
 
 func (h *Handler) QuestionRemove(ctx *gin.Context) {
-	h.logger.InfoF("QuestionRemove handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionRemove handler received by: %s", ctx.Request.UserAgent())
 
 	questionEntity := new(entities.QuestionRemove)
 	if err := ctx.ShouldBindBodyWithJSON(questionEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
 	variant := ctx.MustGet("variant").(*entities.Variant)
 
-	if err := h.service.QuestionsService.QuestionRemove(ctx.Request.Context(), variant.Id, questionEntity); err != nil {
-		if errors.Is(err, constants.ErrorVariantNotFound) || errors.Is(err, constants.ErrorQuestionNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.service.QuestionsService.QuestionRemove(ctx.Request.Context(), variant.OrganizationId, variant.Id, questionEntity); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Question removed successfully", nil)
+	return
+}
+
+// QuestionDelete removes the question named by its id in the path, so the
+// DELETE carries no body.
+func (h *Handler) QuestionDelete(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionDelete handler received by: %s", ctx.Request.UserAgent())
+
+	questionId, _ := strconv.Atoi(ctx.Param("questionId"))
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	question, err := h.service.QuestionsService.QuestionGet(ctx.Request.Context(), variant.OrganizationId, variant.Id, questionId)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	removeEntity := &entities.QuestionRemove{VariantName: variant.Name, Question: question.Question}
+	if err := h.service.QuestionsService.QuestionRemove(ctx.Request.Context(), variant.OrganizationId, variant.Id, removeEntity); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -66,46 +72,62 @@ func (h *Handler) QuestionRemove(ctx *gin.Context) {
 }
 
 func (h *Handler) QuestionGet(ctx *gin.Context) {
-	h.logger.InfoF("QuestionGet handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionGet handler received by: %s", ctx.Request.UserAgent())
 
 	questionId, _ := strconv.Atoi(ctx.Param("questionId"))
 	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
 
-	question, err := h.service.QuestionsService.QuestionGet(ctx.Request.Context(), variant.Id, questionId)
+	if err := h.service.VariantService.VariantAccess(ctx.Request.Context(), variant, user); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	question, err := h.service.QuestionsService.QuestionGet(ctx.Request.Context(), variant.OrganizationId, variant.Id, questionId)
 	if err != nil {
-		if errors.Is(err, constants.ErrorQuestionNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
-	NewSuccessResponse(ctx, http.StatusOK, "question", question)
+	NewSuccessResponse(ctx, http.StatusOK, "question", h.service.QuestionsService.QuestionView(variant, user, question))
 	return
 }
 
 func (h *Handler) QuestionAccept(ctx *gin.Context) {
-	h.logger.InfoF("QuestionAccept handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionAccept handler received by: %s", ctx.Request.UserAgent())
 
-	answerEntity := new(entities.Answer)
+	answerEntity := new(entities.UserAnswer)
 	if err := ctx.ShouldBindBodyWithJSON(answerEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
+	questionId, _ := strconv.Atoi(ctx.Param("questionId"))
 	variant := ctx.MustGet("variant").(*entities.Variant)
 	user := ctx.MustGet("user").(*entities.User)
 
-	if err := h.service.QuestionsService.QuestionAccept(ctx.Request.Context(), variant.Id, user.ID, answerEntity.Answer); err != nil {
-		if errors.Is(err, constants.ErrorQuestionNotFound) || errors.Is(err, constants.ErrorTestNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.service.QuestionsService.QuestionAccept(ctx.Request.Context(), variant, user, questionId, answerEntity); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
 	NewSuccessResponse(ctx, http.StatusOK, "answer accepted", nil)
 	return
 }
+
+func (h *Handler) QuestionHint(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("QuestionHint handler received by: %s", ctx.Request.UserAgent())
+
+	questionId, _ := strconv.Atoi(ctx.Param("questionId"))
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	hint, err := h.service.QuestionsService.QuestionHint(ctx.Request.Context(), variant, user, questionId)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "hint", hint)
+	return
+}
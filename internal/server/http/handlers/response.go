@@ -1,17 +1,141 @@
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"quiz-service/pkg/constants"
+	"reflect"
+	"strings"
+)
 
 type Response struct {
 	Status  int         `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Error   *Error      `json:"error,omitempty"`
+}
+
+// Error is the machine-readable part of an error response. Code comes from
+// constants.Error and is stable, Details point at the offending fields.
+type Error struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details []*ErrorDetail `json:"details,omitempty"`
+}
+
+type ErrorDetail struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
 }
 
 func NewSuccessResponse(ctx *gin.Context, status int, message string, data interface{}) {
 	ctx.AbortWithStatusJSON(status, Response{Status: status, Message: message, Data: data})
 }
 
-func NewErrorResponse(ctx *gin.Context, status int, message string) {
-	ctx.AbortWithStatusJSON(status, Response{Status: status, Message: message})
+// NewErrorResponse answers with the status and code of a constants.Error. A
+// missed deadline is a timeout, any other error is internal and its text stays
+// in the logs.
+func NewErrorResponse(ctx *gin.Context, err error) {
+	NewErrorStatusResponse(ctx, 0, err)
+}
+
+// NewErrorStatusResponse is NewErrorResponse with the status overridden, for
+// handlers where the error means something else, e.g. an unknown user on login.
+func NewErrorStatusResponse(ctx *gin.Context, status int, err error, details ...*ErrorDetail) {
+	var typed *constants.Error
+	switch {
+	case errors.As(err, &typed):
+	case errors.Is(err, context.DeadlineExceeded):
+		typed = constants.ErrorTimeout
+	default:
+		typed = constants.ErrorInternal
+	}
+	if status == 0 {
+		status = typed.Status
+	}
+
+	ctx.AbortWithStatusJSON(status, Response{
+		Status:  status,
+		Message: typed.Message,
+		Error:   &Error{Code: typed.Code, Message: typed.Message, Details: details},
+	})
+}
+
+// NewBindingResponse reports a request body that failed to decode or validate,
+// with one detail per field.
+func NewBindingResponse(ctx *gin.Context, err error) {
+	var (
+		details     []*ErrorDetail
+		validation  validator.ValidationErrors
+		typeMistake *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &validation):
+		for _, field := range validation {
+			details = append(details, &ErrorDetail{Field: fieldPath(field), Rule: field.Tag(), Message: ruleMessage(field)})
+		}
+	case errors.As(err, &typeMistake):
+		details = append(details, &ErrorDetail{Field: typeMistake.Field, Rule: "type", Message: "must be " + typeMistake.Type.String()})
+	}
+
+	NewErrorStatusResponse(ctx, 0, constants.ErrorInvalidBody, details...)
+}
+
+// NewParamResponse reports a malformed path parameter.
+func NewParamResponse(ctx *gin.Context, param, message string) {
+	NewErrorStatusResponse(ctx, 0, constants.ErrorInvalidParameter, &ErrorDetail{Field: param, Message: message})
+}
+
+// UseJSONFieldNames makes validation errors name fields the way clients send
+// them. It changes the shared gin validator, so it is called once at startup.
+func UseJSONFieldNames() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+}
+
+// fieldPath drops the struct name from the namespace: "Question.answers[0].answer"
+// becomes "answers[0].answer".
+func fieldPath(field validator.FieldError) string {
+	_, path, found := strings.Cut(field.Namespace(), ".")
+	if !found {
+		return field.Field()
+	}
+	return path
+}
+
+func ruleMessage(field validator.FieldError) string {
+	switch field.Tag() {
+	case "required", "required_without":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s", field.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", field.Param())
+	case "len":
+		return fmt.Sprintf("must have length %s", field.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", field.Param())
+	case "url":
+		return "must be a url"
+	default:
+		return fmt.Sprintf("fails the %s rule", field.Tag())
+	}
 }
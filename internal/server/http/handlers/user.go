@@ -4,39 +4,52 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
 	"quiz-service/pkg/constants"
 )
 
 func (h *Handler) Authenticated(ctx *gin.Context) {
-	h.logger.InfoF("Authenticated handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("Authenticated handler received by: %s", ctx.Request.UserAgent())
 
-	user, err := h.service.UserService.Authenticated(ctx.Request.Context(), ctx.Param("userId"))
+	user, err := h.service.UserService.Authenticated(ctx.Request.Context(), ctx.GetString("uuid"))
 	if err != nil {
-		if errors.Is(err, constants.ErrorUserNotFound) || errors.Is(err, constants.ErrorUserNotAuthorized) {
-			NewErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, constants.ErrorUserNotFound) {
+			NewErrorStatusResponse(ctx, http.StatusUnauthorized, err)
 			return
 		}
-
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
 	ctx.Set("user", user)
+	ctx.Request = ctx.Request.WithContext(logger.ContextWithFields(ctx.Request.Context(), map[string]interface{}{
+		logger.UserIDField: user.ID,
+	}))
+	ctx.Next()
+}
+
+// Admin lets only admins through; it runs after Authenticated.
+func (h *Handler) Admin(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("Admin handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.UserService.UserAdmin(user); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
 	ctx.Next()
 }
 
 func (h *Handler) Quit(ctx *gin.Context) {
-	h.logger.InfoF("Quit handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("Quit handler received by: %s", ctx.Request.UserAgent())
 
 	user := ctx.MustGet("user").(*entities.User)
 
 	if err := h.service.UserService.Quit(ctx.Request.Context(), user.UUID); err != nil {
-		if errors.Is(err, constants.ErrorUserNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
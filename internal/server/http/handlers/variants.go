@@ -1,32 +1,24 @@
 package handlers
 
 import (
-	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"quiz-service/internal/entities"
-	"quiz-service/pkg/constants"
 )
 
 func (h *Handler) VariantAdd(ctx *gin.Context) {
-	h.logger.InfoF("VariantAdd handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantAdd handler received by: %s", ctx.Request.UserAgent())
 
 	questionEntity := new(entities.Variant)
 	if err := ctx.ShouldBindBodyWithJSON(questionEntity); err != nil {
-		NewErrorResponse(ctx, http.StatusBadRequest, "Invalid request body")
+		NewBindingResponse(ctx, err)
 		return
 	}
 
-	if err := h.service.VariantService.VariantAdd(ctx.Request.Context(), questionEntity.Name); err != nil {
-		if errors.Is(err, constants.ErrorVariantAlreadyExists) {
-			NewErrorResponse(ctx, http.StatusConflict, err.Error())
-			return
-		}
-		if errors.Is(err, constants.ErrorVariantTooLong) {
-			NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.VariantService.VariantAdd(ctx.Request.Context(), user.OrganizationId, user.ID, questionEntity); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -34,17 +26,33 @@ func (h *Handler) VariantAdd(ctx *gin.Context) {
 	return
 }
 
+func (h *Handler) VariantSettings(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantSettings handler received by: %s", ctx.Request.UserAgent())
+
+	settingsEntity := new(entities.VariantSettings)
+	if err := ctx.ShouldBindBodyWithJSON(settingsEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+
+	if err := h.service.VariantService.VariantSettings(ctx.Request.Context(), variant.OrganizationId, variant.Id, settingsEntity); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Variant settings updated", nil)
+	return
+}
+
 func (h *Handler) VariantRemove(ctx *gin.Context) {
-	h.logger.InfoF("VariantRemove handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantRemove handler received by: %s", ctx.Request.UserAgent())
 
 	variant := ctx.MustGet("variant").(*entities.Variant)
 
-	if err := h.service.VariantService.VariantRemove(ctx.Request.Context(), variant.Name); err != nil {
-		if errors.Is(err, constants.ErrorVariantNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.service.VariantService.VariantRemove(ctx.Request.Context(), variant.OrganizationId, variant.Name); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -53,33 +61,40 @@ func (h *Handler) VariantRemove(ctx *gin.Context) {
 }
 
 func (h *Handler) VariantList(ctx *gin.Context) {
-	h.logger.InfoF("VariantList handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantList handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
 
-	variants, err := h.service.VariantService.VariantList(ctx.Request.Context())
+	variants, err := h.service.VariantService.VariantList(ctx.Request.Context(), user.OrganizationId)
 	if err != nil {
-		if errors.Is(err, constants.ErrorNoVariantsYet) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
-	NewSuccessResponse(ctx, http.StatusOK, "all variants", variants)
+	variants, err = h.service.VariantService.VariantAllowed(ctx.Request.Context(), variants, user)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	views := make([]*entities.Variant, 0, len(variants))
+	for _, variant := range variants {
+		views = append(views, h.service.VariantService.VariantView(variant, user))
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "all variants", views)
 	return
 }
 
 func (h *Handler) VariantCheck(ctx *gin.Context) {
-	h.logger.InfoF("VariantCheck handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantCheck handler received by: %s", ctx.Request.UserAgent())
 
 	variantName := ctx.Param("variantName")
-	variant, err := h.service.VariantService.VariantGet(ctx.Request.Context(), variantName)
+	user := ctx.MustGet("user").(*entities.User)
+
+	variant, err := h.service.VariantService.VariantGet(ctx.Request.Context(), user.OrganizationId, variantName)
 	if err != nil {
-		if errors.Is(err, constants.ErrorVariantNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
@@ -87,50 +102,104 @@ func (h *Handler) VariantCheck(ctx *gin.Context) {
 	ctx.Next()
 }
 
+// VariantAuthor lets only the variant's author or an admin through; it runs
+// after VariantCheck.
+func (h *Handler) VariantAuthor(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantAuthor handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.VariantService.VariantAuthor(variant, user); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Next()
+}
+
 func (h *Handler) VariantGet(ctx *gin.Context) {
-	h.logger.InfoF("VariantGet handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantGet handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.VariantService.VariantAccess(ctx.Request.Context(), variant, user); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
 
-	NewSuccessResponse(ctx, http.StatusOK, "variant", ctx.MustGet("variant").(*entities.Variant))
+	NewSuccessResponse(ctx, http.StatusOK, "variant", h.service.VariantService.VariantView(variant, user))
 	return
 }
 
 func (h *Handler) VariantStart(ctx *gin.Context) {
-	h.logger.InfoF("VariantStart handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantStart handler received by: %s", ctx.Request.UserAgent())
 
 	variant := ctx.MustGet("variant").(*entities.Variant)
 	user := ctx.MustGet("user").(*entities.User)
 
-	if err := h.service.VariantService.VariantStart(ctx.Request.Context(), variant.Id, user.ID); err != nil {
-		if errors.Is(err, constants.ErrorVariantNotFound) {
-			NewErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, constants.ErrorVariantCompleted) {
-			NewErrorResponse(ctx, http.StatusConflict, err.Error())
-			return
-		}
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.service.VariantService.VariantStart(ctx.Request.Context(), variant, user); err != nil {
+		NewErrorResponse(ctx, err)
 		return
 	}
 
-	NewSuccessResponse(ctx, http.StatusOK, "variant successfully started", variant)
+	NewSuccessResponse(ctx, http.StatusOK, "variant successfully started", h.service.VariantService.VariantView(variant, user))
 	return
 }
 
 func (h *Handler) VariantResults(ctx *gin.Context) {
-	h.logger.InfoF("VariantResults handler received by: %s", ctx.Request.UserAgent())
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantResults handler received by: %s", ctx.Request.UserAgent())
 
 	variant := ctx.MustGet("variant").(*entities.Variant)
 	user := ctx.MustGet("user").(*entities.User)
 
-	testing, err := h.service.VariantService.VariantResults(ctx.Request.Context(), variant.Id, user.ID)
+	testing, err := h.service.VariantService.VariantResults(ctx.Request.Context(), variant, user)
 	if err != nil {
-		NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		NewErrorResponse(ctx, err)
 		return
 	}
 
 	ctx.HTML(http.StatusOK, "results.html", gin.H{
 		"correctAnswers": testing.CorrectAnswers,
+		"penalty":        testing.Penalty,
+		"score":          testing.Score,
+		"maxScore":       testing.MaxScore,
+		"passed":         testing.Passed,
 	})
 	return
 }
+
+// VariantFinish finishes the attempt like VariantResults, answering with data
+// instead of the results page.
+func (h *Handler) VariantFinish(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantFinish handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	testing, err := h.service.VariantService.VariantResults(ctx.Request.Context(), variant, user)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "results", testing)
+	return
+}
+
+func (h *Handler) VariantReview(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("VariantReview handler received by: %s", ctx.Request.UserAgent())
+
+	variant := ctx.MustGet("variant").(*entities.Variant)
+	user := ctx.MustGet("user").(*entities.User)
+
+	reviews, err := h.service.VariantService.VariantReview(ctx.Request.Context(), variant, user.ID)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "review", reviews)
+	return
+}
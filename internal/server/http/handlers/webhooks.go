@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"quiz-service/internal/entities"
+	"strconv"
+)
+
+func (h *Handler) WebhookAdd(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("WebhookAdd handler received by: %s", ctx.Request.UserAgent())
+
+	webhookEntity := new(entities.Webhook)
+	if err := ctx.ShouldBindBodyWithJSON(webhookEntity); err != nil {
+		NewBindingResponse(ctx, err)
+		return
+	}
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	webhook, err := h.service.WebhooksService.WebhookAdd(ctx.Request.Context(), user.OrganizationId, webhookEntity)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusCreated, "Webhook successfully created", webhook)
+	return
+}
+
+func (h *Handler) WebhookList(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("WebhookList handler received by: %s", ctx.Request.UserAgent())
+
+	user := ctx.MustGet("user").(*entities.User)
+
+	webhooks, err := h.service.WebhooksService.WebhookList(ctx.Request.Context(), user.OrganizationId)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "all webhooks", webhooks)
+	return
+}
+
+func (h *Handler) WebhookRemove(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("WebhookRemove handler received by: %s", ctx.Request.UserAgent())
+
+	webhookId, _ := strconv.Atoi(ctx.Param("webhookId"))
+	user := ctx.MustGet("user").(*entities.User)
+
+	if err := h.service.WebhooksService.WebhookRemove(ctx.Request.Context(), user.OrganizationId, webhookId); err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "Webhook successfully removed", nil)
+	return
+}
+
+func (h *Handler) WebhookDeliveries(ctx *gin.Context) {
+	h.logger.WithContext(ctx.Request.Context()).InfoF("WebhookDeliveries handler received by: %s", ctx.Request.UserAgent())
+
+	webhookId, _ := strconv.Atoi(ctx.Param("webhookId"))
+	user := ctx.MustGet("user").(*entities.User)
+
+	deliveries, err := h.service.WebhooksService.WebhookDeliveries(ctx.Request.Context(), user.OrganizationId, webhookId)
+	if err != nil {
+		NewErrorResponse(ctx, err)
+		return
+	}
+
+	NewSuccessResponse(ctx, http.StatusOK, "webhook deliveries", deliveries)
+	return
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"quiz-service/internal/server/http/handlers"
+	"quiz-service/pkg/constants"
+	"strings"
+)
+
+// Bearer takes the user's uuid from "Authorization: Bearer <uuid>", the /api/v1
+// counterpart of the :userId route segment.
+func Bearer() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scheme, id, _ := strings.Cut(ctx.GetHeader("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || id == "" {
+			ctx.Header("WWW-Authenticate", "Bearer")
+			handlers.NewErrorResponse(ctx, constants.ErrorUserNotAuthorized)
+			return
+		}
+
+		if _, err := uuid.Parse(id); err != nil {
+			ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			handlers.NewErrorStatusResponse(ctx, 0, constants.ErrorUserNotAuthorized,
+				&handlers.ErrorDetail{Field: "Authorization", Message: "must be a uuid"})
+			return
+		}
+
+		ctx.Set("uuid", id)
+		ctx.Next()
+	}
+}
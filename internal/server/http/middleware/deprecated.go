@@ -0,0 +1,13 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// Deprecated marks the routes of a group as superseded by the successor path,
+// see RFC 8594. They keep working until they are removed.
+func Deprecated(successor string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", "true")
+		ctx.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		ctx.Next()
+	}
+}
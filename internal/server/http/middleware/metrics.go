@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"quiz-service/internal/metrics"
+	"strconv"
+	"time"
+)
+
+// Metrics counts and times requests by route template rather than by path, so
+// /api/v1/variants/a and /api/v1/variants/b share a series. Requests matching
+// no route are all reported as "unmatched".
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+
+		metrics.HTTPRequests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		metrics.HTTPDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
-	"net/http"
 	"quiz-service/internal/server/http/handlers"
 	"strconv"
 )
@@ -10,7 +9,7 @@ import (
 func QuestionId() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		if _, err := strconv.Atoi(ctx.Param("questionId")); err != nil {
-			handlers.NewErrorResponse(ctx, http.StatusBadRequest, "Question id must be a positive integer")
+			handlers.NewParamResponse(ctx, "questionId", "must be a positive integer")
 			return
 		}
 
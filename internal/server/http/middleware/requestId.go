@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"quiz-service/init/logger"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength keeps a client from filling the logs through the header
+	maxRequestIDLength = 128
+)
+
+// RequestID takes the caller's X-Request-ID, or generates one, and echoes it in
+// the response. The id and the route go into the request context, where
+// logger.WithContext picks them up for every line logged while serving it.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		ctx.Set("request_id", id)
+		ctx.Header(RequestIDHeader, id)
+		ctx.Request = ctx.Request.WithContext(logger.ContextWithFields(ctx.Request.Context(), map[string]interface{}{
+			logger.RequestIDField: id,
+			logger.RouteField:     ctx.FullPath(),
+		}))
+
+		ctx.Next()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,7 +3,6 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"net/http"
 	"quiz-service/internal/server/http/handlers"
 )
 
@@ -11,15 +10,16 @@ func UserId() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		id := ctx.Param("userId")
 		if id == "" {
-			handlers.NewErrorResponse(ctx, http.StatusBadRequest, "No id supplied")
+			handlers.NewParamResponse(ctx, "userId", "no id supplied")
 			return
 		}
 
 		if _, err := uuid.Parse(id); err != nil {
-			handlers.NewErrorResponse(ctx, http.StatusBadRequest, "Invalid id supplied")
+			handlers.NewParamResponse(ctx, "userId", "must be a uuid")
 			return
 		}
 
+		ctx.Set("uuid", id)
 		ctx.Next()
 	}
 }
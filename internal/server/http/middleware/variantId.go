@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
-	"net/http"
 	"quiz-service/internal/server/http/handlers"
 )
 
@@ -10,7 +9,8 @@ func VariantName() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		variantName := ctx.Param("variantName")
 		if variantName == "" {
-			handlers.NewErrorResponse(ctx, http.StatusBadRequest, "no variant name supplied")
+			handlers.NewParamResponse(ctx, "variantName", "no variant name supplied")
+			return
 		}
 
 		ctx.Next()
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"quiz-service/internal/server/http/handlers"
+	"strconv"
+)
+
+func WebhookId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, err := strconv.Atoi(ctx.Param("webhookId")); err != nil {
+			handlers.NewParamResponse(ctx, "webhookId", "must be a positive integer")
+			return
+		}
+
+		ctx.Next()
+	}
+}
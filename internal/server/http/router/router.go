@@ -6,71 +6,282 @@ import (
 	"net/http"
 	"quiz-service/init/config"
 	"quiz-service/init/logger"
+	"quiz-service/internal/events"
 	"quiz-service/internal/repository"
+	"quiz-service/internal/server/http/docs"
 	"quiz-service/internal/server/http/handlers"
 	"quiz-service/internal/server/http/middleware"
 	"quiz-service/internal/service"
+	"quiz-service/pkg/certificate"
 	"quiz-service/pkg/hash"
+	"quiz-service/pkg/openapi"
 )
 
 type Router struct {
-	router  *gin.RouterGroup
-	handler *handlers.Handler
+	router   *gin.RouterGroup
+	handler  *handlers.Handler
+	service  *service.Service
+	document *openapi.Document
 }
 
 func InitRouterAndComponents(router *gin.RouterGroup, db *sqlx.DB, cfg *config.Config, httpLogger, dbLogger, quizLogger *logger.Logger) *Router {
 	repo := repository.NewRepository(db, dbLogger)
 	hasher := hash.NewSHA512Hasher(cfg.PasswordSalt)
-	serv := service.NewService(repo, hasher, quizLogger)
+	generator := certificate.NewPDFGenerator()
+	broker := events.NewBroker()
+	serv := service.NewService(repo, hasher, generator, broker, quizLogger)
 	handler := handlers.NewHandler(serv, httpLogger)
+	handlers.UseJSONFieldNames()
 
 	return &Router{
-		router:  router,
-		handler: handler,
+		router:   router,
+		handler:  handler,
+		service:  serv,
+		document: docs.Spec(router.BasePath()),
 	}
 }
 
+func (r *Router) Service() *service.Service {
+	return r.service
+}
+
+// CloseStreams ends the open event streams, for the server's shutdown.
+func (r *Router) CloseStreams() {
+	r.handler.CloseStreams()
+}
+
+func (r *Router) Document() *openapi.Document {
+	return r.document
+}
+
+// APIVersion is the prefix of the current API. Routes registered without it are
+// deprecated aliases kept while clients migrate.
+const APIVersion = "/api/v1"
+
 func (r *Router) Routes() {
+	r.router.GET("/openapi.json", docs.OpenAPI(r.document))
+	r.router.GET("/docs", docs.SwaggerUI())
+
+	r.pages()
+	r.v1()
+	r.legacy()
+}
+
+// Probes registers the orchestrator endpoints. They go on the engine itself,
+// outside the entry group, so their paths do not depend on the config.
+func (r *Router) Probes(engine gin.IRoutes) {
+	engine.GET("/healthz", r.handler.Healthz)
+	engine.GET("/readyz", r.handler.Readyz)
+	engine.GET("/version", r.handler.Version)
+}
+
+func (r *Router) pages() {
 	r.router.GET("/", func(ctx *gin.Context) {
 		ctx.HTML(http.StatusOK, "register.html", nil)
 	})
 
-	r.router.POST("/register", r.handler.Register)
-	r.router.POST("/login", r.handler.Login)
-
 	user := r.router.Group("/:userId", middleware.UserId(), r.handler.Authenticated)
+	{
+		user.GET("/variant/", func(ctx *gin.Context) {
+			ctx.HTML(http.StatusOK, "variants.html", nil)
+		})
+		user.GET("/variant/:variantName/", r.handler.VariantCheck, func(ctx *gin.Context) {
+			ctx.HTML(http.StatusOK, "test.html", nil)
+		})
+	}
+}
+
+func (r *Router) v1() {
+	api := r.router.Group(APIVersion)
+
+	api.POST("/users", r.handler.Register)
+	api.POST("/sessions", r.handler.Login)
+	api.GET("/certificates/:code", r.handler.CertificateVerify)
+
+	user := api.Group("", middleware.Bearer(), r.handler.Authenticated)
+	{
+		user.DELETE("/sessions", r.handler.Quit)
+		user.POST("/graphql", r.handler.GraphQL)
+		user.POST("/organizations", r.handler.Admin, r.handler.OrganizationAdd)
+
+		groups := user.Group("/groups")
+		{
+			groups.POST("", r.handler.GroupAdd)
+			groups.GET("", r.handler.GroupList)
+
+			groupId := groups.Group("/:groupId", middleware.GroupId(), r.handler.GroupCheck)
+			{
+				groupId.GET("", r.handler.GroupGet)
+				groupId.DELETE("", r.handler.GroupRemove)
+				groupId.POST("/members", r.handler.GroupMembersAdd)
+				groupId.DELETE("/members/:login", r.handler.GroupMemberRemove)
+				groupId.POST("/assignments", r.handler.GroupAssign)
+				groupId.GET("/progress", r.handler.GroupProgress)
+			}
+		}
+
+		webhooks := user.Group("/webhooks", r.handler.Admin)
+		{
+			webhooks.POST("", r.handler.WebhookAdd)
+			webhooks.GET("", r.handler.WebhookList)
+
+			webhookId := webhooks.Group("/:webhookId", middleware.WebhookId())
+			{
+				webhookId.DELETE("", r.handler.WebhookRemove)
+				webhookId.GET("/deliveries", r.handler.WebhookDeliveries)
+			}
+		}
+
+		live := user.Group("/live/:pin")
+		{
+			live.GET("/ws", r.handler.LiveConnect)
+			live.POST("/questions", r.handler.LiveNext)
+			live.DELETE("", r.handler.LiveFinish)
+		}
+
+		variants := user.Group("/variants")
+		{
+			variants.POST("", r.handler.VariantAdd)
+			variants.GET("", r.handler.VariantList)
+
+			variantName := variants.Group("/:variantName", r.handler.VariantCheck)
+			{
+				variantName.GET("", r.handler.VariantGet)
+				variantName.DELETE("", r.handler.VariantAuthor, r.handler.VariantRemove)
+				variantName.PUT("/settings", r.handler.VariantAuthor, r.handler.VariantSettings)
+				variantName.POST("/attempt", r.handler.VariantStart)
+				variantName.POST("/results", r.handler.VariantFinish)
+				variantName.GET("/review", r.handler.VariantReview)
+				variantName.GET("/certificate", r.handler.CertificateDownload)
+				variantName.POST("/redemptions", r.handler.AccessRedeem)
+				variantName.POST("/live", r.handler.LiveOpen)
+				variantName.GET("/proctor/events", r.handler.VariantAuthor, r.handler.ProctorEvents)
+
+				access := variantName.Group("/access", r.handler.VariantAuthor)
+				{
+					access.POST("", r.handler.AccessAdd)
+					access.GET("", r.handler.AccessList)
+					access.DELETE("/:accessId", middleware.AccessId(), r.handler.AccessRemove)
+				}
+
+				practice := variantName.Group("/practice")
+				{
+					practice.POST("", r.handler.PracticeStart)
+					practice.GET("", r.handler.PracticeResults)
+					practice.POST("/results", r.handler.PracticeFinish)
+					practice.POST("/questions/:questionId/answers", middleware.QuestionId(), r.handler.PracticeAccept)
+				}
+
+				questions := variantName.Group("/questions")
+				{
+					questions.POST("", r.handler.VariantAuthor, r.handler.QuestionAdd)
+
+					questionId := questions.Group("/:questionId", middleware.QuestionId())
+					{
+						questionId.GET("", r.handler.QuestionGet)
+						questionId.DELETE("", r.handler.VariantAuthor, r.handler.QuestionDelete)
+						questionId.POST("/answers", r.handler.QuestionAccept)
+						questionId.POST("/hints", r.handler.QuestionHint)
+					}
+				}
+			}
+		}
+	}
+}
+
+// legacy registers the routes that predate /api/v1. They answer exactly as
+// before, plus the Deprecation and Link headers pointing at the successor.
+func (r *Router) legacy() {
+	legacy := r.router.Group("", middleware.Deprecated(r.router.BasePath()+APIVersion))
+
+	legacy.POST("/register", r.handler.Register)
+	legacy.POST("/login", r.handler.Login)
+	legacy.GET("/certificate/:code", r.handler.CertificateVerify)
+
+	user := legacy.Group("/:userId", middleware.UserId(), r.handler.Authenticated)
 	{
 		user.POST("/quit", r.handler.Quit)
+		user.POST("/graphql", r.handler.GraphQL)
+		user.POST("/organization/add", r.handler.Admin, r.handler.OrganizationAdd)
 
-		variants := user.Group("/variant")
+		groups := user.Group("/group")
 		{
-			variants.GET("/", func(ctx *gin.Context) {
-				ctx.HTML(http.StatusOK, "variants.html", nil)
-			})
+			groups.POST("/add", r.handler.GroupAdd)
+			groups.GET("/list", r.handler.GroupList)
 
+			groupId := groups.Group("/:groupId", middleware.GroupId(), r.handler.GroupCheck)
+			{
+				groupId.GET("/get", r.handler.GroupGet)
+				groupId.DELETE("/remove", r.handler.GroupRemove)
+				groupId.POST("/member/add", r.handler.GroupMembersAdd)
+				groupId.DELETE("/member/remove", r.handler.GroupMembersRemove)
+				groupId.POST("/assign", r.handler.GroupAssign)
+				groupId.GET("/progress", r.handler.GroupProgress)
+			}
+		}
+
+		webhooks := user.Group("/webhook", r.handler.Admin)
+		{
+			webhooks.POST("/add", r.handler.WebhookAdd)
+			webhooks.GET("/list", r.handler.WebhookList)
+
+			webhookId := webhooks.Group("/:webhookId", middleware.WebhookId())
+			{
+				webhookId.DELETE("/remove", r.handler.WebhookRemove)
+				webhookId.GET("/deliveries", r.handler.WebhookDeliveries)
+			}
+		}
+
+		live := user.Group("/live/:pin")
+		{
+			live.GET("/ws", r.handler.LiveConnect)
+			live.POST("/next", r.handler.LiveNext)
+			live.POST("/finish", r.handler.LiveFinish)
+		}
+
+		variants := user.Group("/variant")
+		{
 			variants.POST("/add", r.handler.VariantAdd)
 			variants.GET("/list", r.handler.VariantList)
 
 			variantName := variants.Group("/:variantName", r.handler.VariantCheck)
 			{
-				variantName.GET("/", func(ctx *gin.Context) {
-					ctx.HTML(http.StatusOK, "test.html", nil)
-				})
-
-				variantName.DELETE("/remove", r.handler.VariantRemove)
+				variantName.DELETE("/remove", r.handler.VariantAuthor, r.handler.VariantRemove)
+				variantName.PUT("/settings", r.handler.VariantAuthor, r.handler.VariantSettings)
 				variantName.POST("/start", r.handler.VariantStart)
 				variantName.GET("/results", r.handler.VariantResults)
+				variantName.GET("/review", r.handler.VariantReview)
+				variantName.GET("/certificate", r.handler.CertificateDownload)
+				variantName.POST("/redeem", r.handler.AccessRedeem)
+				variantName.POST("/live/open", r.handler.LiveOpen)
+				variantName.GET("/proctor/events", r.handler.VariantAuthor, r.handler.ProctorEvents)
+
+				access := variantName.Group("/access", r.handler.VariantAuthor)
+				{
+					access.POST("/add", r.handler.AccessAdd)
+					access.GET("/list", r.handler.AccessList)
+					access.DELETE("/:accessId/remove", middleware.AccessId(), r.handler.AccessRemove)
+				}
 				variantName.GET("/get", r.handler.VariantGet)
 
+				practice := variantName.Group("/practice")
+				{
+					practice.POST("/start", r.handler.PracticeStart)
+					practice.GET("/results", r.handler.PracticeResults)
+					practice.POST("/finish", r.handler.PracticeFinish)
+					practice.POST("/question/:questionId/accept", middleware.QuestionId(), r.handler.PracticeAccept)
+				}
+
 				question := variantName.Group("/question")
 				{
-					question.POST("/add", r.handler.QuestionAdd)
-					question.DELETE("/remove", r.handler.QuestionRemove)
+					question.POST("/add", r.handler.VariantAuthor, r.handler.QuestionAdd)
+					question.DELETE("/remove", r.handler.VariantAuthor, r.handler.QuestionRemove)
 
 					questionId := question.Group("/:questionId", middleware.QuestionId())
 					{
 						questionId.GET("/get", middleware.QuestionId(), r.handler.QuestionGet)
 						questionId.POST("/accept", middleware.QuestionId(), r.handler.QuestionAccept)
+						questionId.POST("/hint", r.handler.QuestionHint)
 					}
 				}
 			}
@@ -2,18 +2,41 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"golang.org/x/sync/errgroup"
 	"net/http"
 	"quiz-service/init/config"
 	"quiz-service/init/logger"
+	"quiz-service/internal/jobs"
+	"quiz-service/internal/metrics"
 	"quiz-service/internal/repository/postgres"
+	"quiz-service/internal/server/http/docs"
+	"quiz-service/internal/server/http/middleware"
 	"quiz-service/internal/server/http/router"
+	"quiz-service/internal/service"
+	"quiz-service/internal/tracing"
+	"strings"
 	"time"
 )
 
+const (
+	finalizeInterval = time.Minute
+	dispatchInterval = 5 * time.Second
+	reapInterval     = time.Minute
+)
+
 type HTTPServer struct {
-	server *http.Server
+	server     *http.Server
+	admin      *http.Server
+	drain      time.Duration
+	service    *service.Service
+	finalizer  *jobs.Finalizer
+	dispatcher *jobs.Dispatcher
+	reaper     *jobs.Reaper
 }
 
 func NewHTTPServer(ctx context.Context, cfg *config.Config, httpLogger, dbLogger, quizLogger *logger.Logger) (*HTTPServer, error) {
@@ -22,9 +45,17 @@ func NewHTTPServer(ctx context.Context, cfg *config.Config, httpLogger, dbLogger
 		return nil, err
 	}
 
+	metrics.RegisterDB(db)
+
 	engine := setupGin(cfg.Debug)
 	entry := engine.Group(cfg.Entry)
-	router.InitRouterAndComponents(entry, db, cfg, httpLogger, dbLogger, quizLogger).Routes()
+	components := router.InitRouterAndComponents(entry, db, cfg, httpLogger, dbLogger, quizLogger)
+	components.Routes()
+	components.Probes(engine)
+
+	if missing := docs.Missing(components.Document(), engine.Routes(), cfg.Entry); len(missing) > 0 {
+		quizLogger.ErrorF("routes missing from the OpenAPI document: %s", strings.Join(missing, ", "))
+	}
 
 	server := &http.Server{
 		Addr:           fmt.Sprintf(":%d", cfg.Port),
@@ -33,16 +64,73 @@ func NewHTTPServer(ctx context.Context, cfg *config.Config, httpLogger, dbLogger
 		Handler:        engine,
 		MaxHeaderBytes: 1 << 20,
 	}
+	server.RegisterOnShutdown(components.CloseStreams)
+
+	// With a metrics port set /metrics is only reachable there, so it can stay
+	// off the public listener; otherwise it is served next to the API.
+	var admin *http.Server
+	if cfg.MetricsPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		admin = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.MetricsPort),
+			ReadHeaderTimeout: 10 * time.Second,
+			Handler:           mux,
+		}
+	} else {
+		engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+
+	finalizer := jobs.NewFinalizer(components.Service().VariantService, finalizeInterval, quizLogger)
+	dispatcher := jobs.NewDispatcher(components.Service().WebhooksService, dispatchInterval, quizLogger)
+	reaper := jobs.NewReaper(components.Service().LiveService, reapInterval, quizLogger)
+
+	return &HTTPServer{server: server, admin: admin, drain: cfg.ShutdownDrain, service: components.Service(), finalizer: finalizer, dispatcher: dispatcher, reaper: reaper}, nil
+}
 
-	return &HTTPServer{server: server}, nil
+func (s *HTTPServer) Service() *service.Service {
+	return s.service
 }
 
 func (s *HTTPServer) Run() error {
-	return s.server.ListenAndServe()
+	if s.admin == nil {
+		return s.server.ListenAndServe()
+	}
+
+	errs := new(errgroup.Group)
+	errs.Go(s.server.ListenAndServe)
+	errs.Go(s.admin.ListenAndServe)
+	return errs.Wait()
+}
+
+func (s *HTTPServer) RunJobs(ctx context.Context) error {
+	errs, gCtx := errgroup.WithContext(ctx)
+	errs.Go(func() error {
+		return s.finalizer.Run(gCtx)
+	})
+	errs.Go(func() error {
+		return s.dispatcher.Run(gCtx)
+	})
+	errs.Go(func() error {
+		return s.reaper.Run(gCtx)
+	})
+	return errs.Wait()
 }
 
+// Shutdown fails readiness first and keeps serving for the drain period, so the
+// orchestrator can take the instance out of rotation before connections close.
 func (s *HTTPServer) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	s.service.HealthService.Drain()
+
+	select {
+	case <-time.After(s.drain):
+	case <-ctx.Done():
+	}
+
+	if s.admin == nil {
+		return s.server.Shutdown(ctx)
+	}
+	return errors.Join(s.server.Shutdown(ctx), s.admin.Shutdown(ctx))
 }
 
 func setupGin(debug bool) *gin.Engine {
@@ -54,6 +142,9 @@ func setupGin(debug bool) *gin.Engine {
 
 	engine := gin.New()
 	engine.Use(gin.Recovery())
+	engine.Use(middleware.RequestID())
+	engine.Use(otelgin.Middleware(tracing.ServiceName))
+	engine.Use(middleware.Metrics())
 	engine.Use(gin.LoggerWithFormatter(logger.HTTPLogger))
 
 	engine.LoadHTMLFiles("./web/register.html", "./web/variants.html", "./web/test.html", "./web/results.html")
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"quiz-service/init/logger"
+	"quiz-service/internal/events"
+	"quiz-service/pkg/certificate"
 	"quiz-service/pkg/hash"
 
 	"quiz-service/internal/entities"
@@ -10,16 +12,86 @@ import (
 	"quiz-service/internal/service/services"
 )
 
+type AccessService interface {
+	AccessAdd(ctx context.Context, tenantId, variantId int, access *entities.Access) (*entities.Access, error)
+	AccessList(ctx context.Context, tenantId, variantId int) ([]*entities.Access, error)
+	AccessRemove(ctx context.Context, tenantId, variantId, accessId int) error
+	AccessRedeem(ctx context.Context, tenantId, variantId, userId int, code string) error
+}
+
+type CertificateService interface {
+	CertificateDownload(ctx context.Context, tenantId, variantId, userId int) (*entities.Certificate, []byte, error)
+	CertificateVerify(ctx context.Context, code string) (*entities.Certificate, error)
+}
+
+type GraphService interface {
+	GraphVariants(ctx context.Context, tenantId int) ([]*entities.Variant, error)
+	GraphVariantsByIds(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Variant, error)
+	GraphQuestions(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Question, error)
+	GraphAnswers(ctx context.Context, tenantId int, questionIds []int) ([]*entities.Answer, error)
+	GraphAttempts(ctx context.Context, tenantId int, userIds []int) ([]*entities.Testing, error)
+	GraphUsers(ctx context.Context, tenantId int, userIds []int) ([]*entities.User, error)
+}
+
+type GroupsService interface {
+	GroupAdd(ctx context.Context, tenantId, ownerId int, group *entities.Group) (*entities.Group, error)
+	GroupList(ctx context.Context, tenantId, ownerId int) ([]*entities.Group, error)
+	GroupGet(ctx context.Context, tenantId, groupId, ownerId int) (*entities.Group, error)
+	GroupRemove(ctx context.Context, tenantId, groupId int) error
+	GroupMembersAdd(ctx context.Context, tenantId, groupId int, logins []string) (*entities.GroupMembersResult, error)
+	GroupMembersRemove(ctx context.Context, tenantId, groupId int, logins []string) error
+	GroupAssign(ctx context.Context, groupId int, user *entities.User, assignment *entities.GroupAssignment) error
+	GroupProgress(ctx context.Context, tenantId, groupId int) ([]*entities.GroupProgress, error)
+}
+
+type HealthService interface {
+	Drain()
+	Ready(ctx context.Context) error
+	Version(ctx context.Context) (*entities.Version, error)
+}
+
+type LiveService interface {
+	LiveOpen(ctx context.Context, variant *entities.Variant, host *entities.User, open *entities.LiveOpen) (*entities.LiveSession, error)
+	LiveJoin(ctx context.Context, pin string, user *entities.User) (*entities.LiveClient, error)
+	LiveLeave(client *entities.LiveClient)
+	LiveAnswer(ctx context.Context, client *entities.LiveClient, answer *entities.LiveAnswer) (*entities.LiveAnswerResult, error)
+	LiveNext(ctx context.Context, pin string, hostId int) error
+	LiveFinish(ctx context.Context, pin string, hostId int) error
+	LiveExpireIdle(ctx context.Context) (int, error)
+}
+
+type OrganizationsService interface {
+	OrganizationAdd(ctx context.Context, organization *entities.Organization) (*entities.Organization, error)
+	OrganizationGet(ctx context.Context, name string) (*entities.Organization, error)
+}
+
+type ProctorService interface {
+	ProctorSubscribe(variantId int, lastEventId int64) ([]*entities.ProctorEvent, <-chan *entities.ProctorEvent, func())
+}
+
 type QuestionsService interface {
-	QuestionAdd(ctx context.Context, variantId int, question *entities.Question) error
-	QuestionRemove(ctx context.Context, variantId int, question *entities.QuestionRemove) error
-	QuestionGet(ctx context.Context, variantId, questionId int) (*entities.Question, error)
-	QuestionAccept(ctx context.Context, variantId, userId int, answer string) error
+	QuestionAdd(ctx context.Context, tenantId, variantId int, question *entities.Question) error
+	QuestionRemove(ctx context.Context, tenantId, variantId int, question *entities.QuestionRemove) error
+	QuestionGet(ctx context.Context, tenantId, variantId, questionId int) (*entities.Question, error)
+	QuestionView(variant *entities.Variant, user *entities.User, question *entities.Question) *entities.Question
+	QuestionAccept(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int, answer *entities.UserAnswer) error
+	QuestionHint(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int) (*entities.Hint, error)
+}
+
+type PracticeService interface {
+	PracticeStart(ctx context.Context, variant *entities.Variant, user *entities.User) (*entities.Practice, error)
+	PracticeAccept(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int, answer *entities.UserAnswer) (*entities.PracticeFeedback, error)
+	PracticeFinish(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error)
+	PracticeResults(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error)
 }
 
 type UserService interface {
 	Quit(ctx context.Context, uuid string) error
 	Authenticated(ctx context.Context, uuid string) (*entities.User, error)
+	UserAdmin(user *entities.User) error
+	UserPasswordReset(ctx context.Context, login, password string) error
+	UserRoleSet(ctx context.Context, login, role string) error
+	UserDisable(ctx context.Context, login string) error
 }
 
 type RegisterService interface {
@@ -28,26 +100,66 @@ type RegisterService interface {
 }
 
 type VariantService interface {
-	VariantAdd(ctx context.Context, name string) error
-	VariantRemove(ctx context.Context, name string) error
-	VariantList(ctx context.Context) ([]*entities.Variant, error)
-	VariantStart(ctx context.Context, variantId, userId int) error
-	VariantGet(ctx context.Context, variantName string) (*entities.Variant, error)
-	VariantResults(ctx context.Context, variantId, userId int) (*entities.Testing, error)
+	VariantAdd(ctx context.Context, tenantId, authorId int, variant *entities.Variant) error
+	VariantAuthor(variant *entities.Variant, user *entities.User) error
+	VariantView(variant *entities.Variant, user *entities.User) *entities.Variant
+	VariantAccess(ctx context.Context, variant *entities.Variant, user *entities.User) error
+	VariantAllowed(ctx context.Context, variants []*entities.Variant, user *entities.User) ([]*entities.Variant, error)
+	VariantSettings(ctx context.Context, tenantId, variantId int, settings *entities.VariantSettings) error
+	VariantRemove(ctx context.Context, tenantId int, name string) error
+	VariantList(ctx context.Context, tenantId int) ([]*entities.Variant, error)
+	VariantStart(ctx context.Context, variant *entities.Variant, user *entities.User) error
+	VariantGet(ctx context.Context, tenantId int, variantName string) (*entities.Variant, error)
+	VariantResults(ctx context.Context, variant *entities.Variant, user *entities.User) (*entities.Testing, error)
+	VariantReview(ctx context.Context, variant *entities.Variant, userId int) ([]*entities.Review, error)
+	VariantFinalizeExpired(ctx context.Context) (int, error)
+}
+
+type WebhooksService interface {
+	WebhookAdd(ctx context.Context, tenantId int, webhook *entities.Webhook) (*entities.Webhook, error)
+	WebhookList(ctx context.Context, tenantId int) ([]*entities.Webhook, error)
+	WebhookRemove(ctx context.Context, tenantId, webhookId int) error
+	WebhookDeliveries(ctx context.Context, tenantId, webhookId int) ([]*entities.WebhookDelivery, error)
+	WebhookDispatch(ctx context.Context) (int, error)
 }
 
 type Service struct {
+	AccessService
+	CertificateService
+	GraphService
+	GroupsService
+	HealthService
+	LiveService
+	OrganizationsService
+	ProctorService
 	QuestionsService
+	PracticeService
 	UserService
 	RegisterService
 	VariantService
+	WebhooksService
 }
 
-func NewService(repo *repository.Repository, hasher hash.Hasher, log logger.Logging) *Service {
+func NewService(
+	repo *repository.Repository,
+	hasher hash.Hasher,
+	generator certificate.Generator,
+	broker *events.Broker,
+	log logger.Logging) *Service {
 	return &Service{
-		QuestionsService: service.NewQuestions(repo.QuestionsRepository, repo.VariantRepository, repo.TestingRepository, log),
-		UserService:      service.NewUser(repo.UserRepository, log),
-		RegisterService:  service.NewRegister(repo.RegisterRepository, hasher, log),
-		VariantService:   service.NewVariant(repo.VariantRepository, log),
+		AccessService:        service.NewAccess(repo.AccessRepository, log),
+		CertificateService:   service.NewCertificate(repo.CertificateRepository, repo.TestingRepository, generator, log),
+		GraphService:         service.NewGraph(repo.GraphRepository, log),
+		GroupsService:        service.NewGroups(repo.GroupsRepository, repo.VariantRepository, log),
+		HealthService:        service.NewHealth(repo.HealthRepository, log),
+		LiveService:          service.NewLive(repo.LiveRepository, repo.QuestionsRepository, repo.AccessRepository, log),
+		OrganizationsService: service.NewOrganizations(repo.OrganizationsRepository, log),
+		ProctorService:       service.NewProctor(broker),
+		QuestionsService:     service.NewQuestions(repo.QuestionsRepository, repo.VariantRepository, repo.TestingRepository, broker, log),
+		PracticeService:      service.NewPractice(repo.PracticeRepository, repo.QuestionsRepository, repo.AccessRepository, log),
+		UserService:          service.NewUser(repo.UserRepository, hasher, log),
+		RegisterService:      service.NewRegister(repo.RegisterRepository, repo.OrganizationsRepository, hasher, log),
+		VariantService:       service.NewVariant(repo.VariantRepository, repo.TestingRepository, repo.CertificateRepository, repo.AccessRepository, broker, log),
+		WebhooksService:      service.NewWebhooks(repo.WebhooksRepository, log),
 	}
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+	"strings"
+)
+
+type Access struct {
+	repo repository.AccessRepository
+
+	log logger.Logging
+}
+
+func NewAccess(repo repository.AccessRepository, log logger.Logging) *Access {
+	return &Access{repo: repo, log: log}
+}
+
+func (a *Access) AccessAdd(ctx context.Context, tenantId, variantId int, access *entities.Access) (*entities.Access, error) {
+	ctx, span := tracing.Start(ctx, "service.AccessAdd")
+	defer span.End()
+
+	size := 5
+	if access.Kind == entities.AccessInvite {
+		size = 16
+		access.MaxUses = 1
+	} else {
+		access.Kind = entities.AccessCode
+	}
+
+	code, err := randomCode(size)
+	if err != nil {
+		a.log.WithContext(ctx).ErrorF("AccessAdd-randomCode failed: %v", err)
+		return nil, err
+	}
+	access.Code = code
+
+	created, err := a.repo.AccessAdd(ctx, tenantId, variantId, access)
+	if err != nil {
+		a.log.WithContext(ctx).ErrorF("AccessAdd failed: %v", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+func (a *Access) AccessList(ctx context.Context, tenantId, variantId int) ([]*entities.Access, error) {
+	ctx, span := tracing.Start(ctx, "service.AccessList")
+	defer span.End()
+
+	accesses, err := a.repo.AccessList(ctx, tenantId, variantId)
+	if err != nil {
+		a.log.WithContext(ctx).ErrorF("AccessList failed: %v", err)
+		return nil, err
+	}
+	return accesses, nil
+}
+
+func (a *Access) AccessRemove(ctx context.Context, tenantId, variantId, accessId int) error {
+	ctx, span := tracing.Start(ctx, "service.AccessRemove")
+	defer span.End()
+
+	num, err := a.repo.AccessRemove(ctx, tenantId, variantId, accessId)
+	if err != nil {
+		a.log.WithContext(ctx).ErrorF("AccessRemove failed: %v", err)
+		return err
+	}
+	if num == 0 {
+		return constants.ErrorAccessNotFound
+	}
+
+	return nil
+}
+
+func (a *Access) AccessRedeem(ctx context.Context, tenantId, variantId, userId int, code string) error {
+	ctx, span := tracing.Start(ctx, "service.AccessRedeem")
+	defer span.End()
+
+	if err := a.repo.AccessRedeem(ctx, tenantId, variantId, userId, strings.ToUpper(strings.TrimSpace(code))); err != nil {
+		if errors.Is(err, constants.ErrorAccessNotFound) ||
+			errors.Is(err, constants.ErrorAccessExpired) ||
+			errors.Is(err, constants.ErrorAccessExhausted) {
+			return err
+		}
+		a.log.WithContext(ctx).ErrorF("AccessRedeem failed: %v", err)
+		return err
+	}
+	return nil
+}
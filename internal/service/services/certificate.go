@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/certificate"
+	"quiz-service/pkg/constants"
+	"strings"
+)
+
+type Certificate struct {
+	repo        repository.CertificateRepository
+	testingRepo repository.TestingRepository
+
+	log logger.Logging
+
+	generator certificate.Generator
+}
+
+func NewCertificate(
+	repo repository.CertificateRepository,
+	testingRepo repository.TestingRepository,
+	generator certificate.Generator,
+	log logger.Logging) *Certificate {
+	return &Certificate{repo: repo, testingRepo: testingRepo, generator: generator, log: log}
+}
+
+func (c *Certificate) CertificateDownload(ctx context.Context, tenantId, variantId, userId int) (*entities.Certificate, []byte, error) {
+	ctx, span := tracing.Start(ctx, "service.CertificateDownload")
+	defer span.End()
+
+	test, err := c.testingRepo.TestGet(ctx, tenantId, userId, variantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, constants.ErrorTestNotFound
+		}
+		c.log.WithContext(ctx).ErrorF("CertificateDownload-TestGet failed: %v", err)
+		return nil, nil, err
+	}
+
+	cert, err := c.repo.CertificateGet(ctx, tenantId, test.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, constants.ErrorCertificateNotFound
+		}
+		c.log.WithContext(ctx).ErrorF("CertificateDownload failed: %v", err)
+		return nil, nil, err
+	}
+
+	pdf, err := c.generator.Generate(&certificate.Data{
+		Login:    cert.Login,
+		Variant:  cert.Variant,
+		Score:    cert.Score,
+		MaxScore: cert.MaxScore,
+		Date:     cert.IssuedAt,
+		Code:     cert.Code,
+	})
+	if err != nil {
+		c.log.WithContext(ctx).ErrorF("CertificateDownload-Generate failed: %v", err)
+		return nil, nil, err
+	}
+
+	return cert, pdf, nil
+}
+
+func (c *Certificate) CertificateVerify(ctx context.Context, code string) (*entities.Certificate, error) {
+	ctx, span := tracing.Start(ctx, "service.CertificateVerify")
+	defer span.End()
+
+	cert, err := c.repo.CertificateVerify(ctx, strings.ToUpper(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorCertificateNotFound
+		}
+		c.log.WithContext(ctx).ErrorF("CertificateVerify failed: %v", err)
+		return nil, err
+	}
+	return cert, nil
+}
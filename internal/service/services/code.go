@@ -0,0 +1,16 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// randomCode returns size random bytes as an upper-case hex string.
+func randomCode(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
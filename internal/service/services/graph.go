@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"time"
+)
+
+type Graph struct {
+	repo repository.GraphRepository
+
+	log logger.Logging
+}
+
+func NewGraph(repo repository.GraphRepository, log logger.Logging) *Graph {
+	return &Graph{repo: repo, log: log}
+}
+
+func (g *Graph) GraphVariants(ctx context.Context, tenantId int) ([]*entities.Variant, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphVariants")
+	defer span.End()
+
+	variants, err := g.repo.GraphVariants(ctx, tenantId)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphVariants failed: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, variant := range variants {
+		variant.Status = variantStatus(variant, now)
+	}
+
+	return variants, nil
+}
+
+func (g *Graph) GraphVariantsByIds(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Variant, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphVariantsByIds")
+	defer span.End()
+
+	variants, err := g.repo.GraphVariantsByIds(ctx, tenantId, variantIds)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphVariantsByIds failed: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, variant := range variants {
+		variant.Status = variantStatus(variant, now)
+	}
+
+	return variants, nil
+}
+
+func (g *Graph) GraphQuestions(ctx context.Context, tenantId int, variantIds []int) ([]*entities.Question, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphQuestions")
+	defer span.End()
+
+	questions, err := g.repo.GraphQuestions(ctx, tenantId, variantIds)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphQuestions failed: %v", err)
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (g *Graph) GraphAnswers(ctx context.Context, tenantId int, questionIds []int) ([]*entities.Answer, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphAnswers")
+	defer span.End()
+
+	answers, err := g.repo.GraphAnswers(ctx, tenantId, questionIds)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphAnswers failed: %v", err)
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (g *Graph) GraphAttempts(ctx context.Context, tenantId int, userIds []int) ([]*entities.Testing, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphAttempts")
+	defer span.End()
+
+	attempts, err := g.repo.GraphAttempts(ctx, tenantId, userIds)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphAttempts failed: %v", err)
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (g *Graph) GraphUsers(ctx context.Context, tenantId int, userIds []int) ([]*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "service.GraphUsers")
+	defer span.End()
+
+	users, err := g.repo.GraphUsers(ctx, tenantId, userIds)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GraphUsers failed: %v", err)
+		return nil, err
+	}
+	return users, nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+type Groups struct {
+	repo        repository.GroupsRepository
+	variantRepo repository.VariantRepository
+
+	log logger.Logging
+}
+
+func NewGroups(repo repository.GroupsRepository, variantRepo repository.VariantRepository, log logger.Logging) *Groups {
+	return &Groups{repo: repo, variantRepo: variantRepo, log: log}
+}
+
+func (g *Groups) GroupAdd(ctx context.Context, tenantId, ownerId int, group *entities.Group) (*entities.Group, error) {
+	ctx, span := tracing.Start(ctx, "service.GroupAdd")
+	defer span.End()
+
+	created, err := g.repo.GroupAdd(ctx, tenantId, ownerId, group.Name)
+	if err != nil {
+		if errors.Is(err, constants.ErrorGroupAlreadyExists) {
+			return nil, err
+		}
+		g.log.WithContext(ctx).ErrorF("GroupAdd failed: %v", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+func (g *Groups) GroupList(ctx context.Context, tenantId, ownerId int) ([]*entities.Group, error) {
+	ctx, span := tracing.Start(ctx, "service.GroupList")
+	defer span.End()
+
+	groups, err := g.repo.GroupList(ctx, tenantId, ownerId)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupList failed: %v", err)
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (g *Groups) GroupGet(ctx context.Context, tenantId, groupId, ownerId int) (*entities.Group, error) {
+	ctx, span := tracing.Start(ctx, "service.GroupGet")
+	defer span.End()
+
+	group, err := g.repo.GroupGet(ctx, tenantId, groupId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorGroupNotFound
+		}
+		g.log.WithContext(ctx).ErrorF("GroupGet failed: %v", err)
+		return nil, err
+	}
+
+	if group.OwnerId != ownerId {
+		return nil, constants.ErrorGroupNotOwner
+	}
+
+	return group, nil
+}
+
+func (g *Groups) GroupRemove(ctx context.Context, tenantId, groupId int) error {
+	ctx, span := tracing.Start(ctx, "service.GroupRemove")
+	defer span.End()
+
+	num, err := g.repo.GroupRemove(ctx, tenantId, groupId)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupRemove failed: %v", err)
+		return err
+	}
+	if num == 0 {
+		return constants.ErrorGroupNotFound
+	}
+
+	return nil
+}
+
+func (g *Groups) GroupMembersAdd(ctx context.Context, tenantId, groupId int, logins []string) (*entities.GroupMembersResult, error) {
+	ctx, span := tracing.Start(ctx, "service.GroupMembersAdd")
+	defer span.End()
+
+	found, err := g.repo.GroupMembersAdd(ctx, tenantId, groupId, logins)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupMembersAdd failed: %v", err)
+		return nil, err
+	}
+
+	added := make(map[string]bool, len(found))
+	for _, login := range found {
+		added[login] = true
+	}
+
+	result := &entities.GroupMembersResult{Logins: found}
+	for _, login := range logins {
+		if !added[login] {
+			result.NotFound = append(result.NotFound, login)
+		}
+	}
+
+	return result, nil
+}
+
+func (g *Groups) GroupMembersRemove(ctx context.Context, tenantId, groupId int, logins []string) error {
+	ctx, span := tracing.Start(ctx, "service.GroupMembersRemove")
+	defer span.End()
+
+	num, err := g.repo.GroupMembersRemove(ctx, tenantId, groupId, logins)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupMembersRemove failed: %v", err)
+		return err
+	}
+	if num == 0 {
+		return constants.ErrorUserNotFound
+	}
+
+	return nil
+}
+
+// GroupAssign assigns only a variant the user authored, or any as an admin:
+// an assignment grants the members access to a restricted variant.
+func (g *Groups) GroupAssign(ctx context.Context, groupId int, user *entities.User, assignment *entities.GroupAssignment) error {
+	ctx, span := tracing.Start(ctx, "service.GroupAssign")
+	defer span.End()
+
+	variant, err := g.variantRepo.VariantGet(ctx, user.OrganizationId, assignment.VariantName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, constants.ErrorVariantNotFound) {
+			return constants.ErrorVariantNotFound
+		}
+		g.log.WithContext(ctx).ErrorF("GroupAssign-VariantGet failed: %v", err)
+		return err
+	}
+
+	if err := variantAuthor(variant, user); err != nil {
+		return err
+	}
+
+	if err := g.repo.GroupAssign(ctx, user.OrganizationId, groupId, variant.Id, assignment.DueAt); err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupAssign failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (g *Groups) GroupProgress(ctx context.Context, tenantId, groupId int) ([]*entities.GroupProgress, error) {
+	ctx, span := tracing.Start(ctx, "service.GroupProgress")
+	defer span.End()
+
+	progress, err := g.repo.GroupProgress(ctx, tenantId, groupId)
+	if err != nil {
+		g.log.WithContext(ctx).ErrorF("GroupProgress failed: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, p := range progress {
+		switch {
+		case p.FinishAt != nil:
+			p.Status = entities.ProgressCompleted
+		case p.StartAt != nil:
+			p.Status = entities.ProgressInProgress
+		default:
+			p.Status = entities.ProgressNotStarted
+		}
+
+		if p.DueAt != nil {
+			p.Overdue = (p.FinishAt == nil && now.After(*p.DueAt)) || (p.FinishAt != nil && p.FinishAt.After(*p.DueAt))
+		}
+	}
+
+	return progress, nil
+}
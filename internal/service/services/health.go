@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/pkg/constants"
+	"quiz-service/pkg/version"
+	"sync"
+	"sync/atomic"
+)
+
+type Health struct {
+	repo repository.HealthRepository
+
+	draining atomic.Bool
+
+	// the migrations cannot change while the process runs, so the expected
+	// version is read from them once
+	expectedOnce sync.Once
+	expected     uint
+	expectedErr  error
+
+	log logger.Logging
+}
+
+func NewHealth(repo repository.HealthRepository, log logger.Logging) *Health {
+	return &Health{repo: repo, log: log}
+}
+
+// Drain makes Ready fail from now on, so the orchestrator stops routing
+// traffic here while in-flight requests finish.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *Health) Ready(ctx context.Context) error {
+	if h.draining.Load() {
+		return constants.ErrorDraining
+	}
+
+	if err := h.repo.Ping(ctx); err != nil {
+		h.log.WithContext(ctx).ErrorF("Ready-Ping failed: %v", err)
+		return constants.ErrorDatabaseUnavailable
+	}
+
+	schema, err := h.repo.SchemaVersion(ctx)
+	if err != nil {
+		h.log.WithContext(ctx).ErrorF("Ready-SchemaVersion failed: %v", err)
+		return constants.ErrorSchemaVersion
+	}
+
+	expected, err := h.schemaExpected()
+	if err != nil {
+		return err
+	}
+	if schema.Dirty || schema.Version != expected {
+		return constants.ErrorSchemaVersion
+	}
+
+	return nil
+}
+
+// Version reports the build and the schema; the schema is left out when the
+// database cannot be reached, the build info is always there.
+func (h *Health) Version(ctx context.Context) (*entities.Version, error) {
+	expected, err := h.schemaExpected()
+	if err != nil {
+		return nil, err
+	}
+
+	versionEntity := &entities.Version{Commit: version.Commit, BuildTime: version.BuildTime, Expected: expected}
+
+	schema, err := h.repo.SchemaVersion(ctx)
+	if err != nil {
+		h.log.WithContext(ctx).ErrorF("Version-SchemaVersion failed: %v", err)
+		return versionEntity, nil
+	}
+	versionEntity.Schema = schema
+
+	return versionEntity, nil
+}
+
+func (h *Health) schemaExpected() (uint, error) {
+	h.expectedOnce.Do(func() {
+		h.expected, h.expectedErr = h.repo.SchemaExpected()
+		if h.expectedErr != nil {
+			h.log.ErrorF("SchemaExpected failed: %v", h.expectedErr)
+		}
+	})
+	return h.expected, h.expectedErr
+}
@@ -0,0 +1,517 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	liveQuestionSeconds = 20
+	// liveSpeedBonus is the share of a question's points added for an instant answer,
+	// shrinking linearly to nothing at the deadline.
+	liveSpeedBonus = 0.5
+	liveBuffer     = 32
+	// liveIdle is how long a session lives on without its host connected;
+	// LiveExpireIdle finishes it after that.
+	liveIdle = 15 * time.Minute
+)
+
+type liveParticipant struct {
+	userId   int
+	login    string
+	score    float64
+	correct  int
+	answered map[int]bool
+	client   *entities.LiveClient
+}
+
+type liveSession struct {
+	mu sync.Mutex
+
+	session      *entities.LiveSession
+	tenantId     int
+	variant      *entities.Variant
+	questions    []*entities.Question
+	current      int
+	open         bool
+	openedAt     time.Time
+	deadline     time.Time
+	host         *entities.LiveClient
+	participants map[int]*liveParticipant
+	// idleSince is when the host left, or the session was opened; zero while
+	// the host is connected
+	idleSince time.Time
+}
+
+// Live is the hub of live sessions. Session state lives in memory for the
+// duration of the game, only the session itself and the final leaderboard are
+// persisted.
+type Live struct {
+	repo         repository.LiveRepository
+	questionRepo repository.QuestionsRepository
+	accessRepo   repository.AccessRepository
+
+	log logger.Logging
+
+	mu       sync.Mutex
+	sessions map[string]*liveSession
+}
+
+func NewLive(
+	repo repository.LiveRepository,
+	questionRepo repository.QuestionsRepository,
+	accessRepo repository.AccessRepository,
+	log logger.Logging) *Live {
+	return &Live{
+		repo:         repo,
+		questionRepo: questionRepo,
+		accessRepo:   accessRepo,
+		log:          log,
+		sessions:     make(map[string]*liveSession),
+	}
+}
+
+// LiveOpen holds the variant to its schedule like VariantStart. A restricted
+// variant needs an invitation, unless the host is its author or an admin.
+func (l *Live) LiveOpen(ctx context.Context, variant *entities.Variant, host *entities.User, open *entities.LiveOpen) (*entities.LiveSession, error) {
+	ctx, span := tracing.Start(ctx, "service.LiveOpen")
+	defer span.End()
+
+	if err := variantWindow(variant, time.Now()); err != nil {
+		return nil, err
+	}
+	if variantAuthor(variant, host) != nil {
+		if err := variantAccess(ctx, l.accessRepo, l.log, variant, host.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(variant.Questions) == 0 {
+		return nil, constants.ErrorLiveNoQuestions
+	}
+
+	questions := make([]*entities.Question, 0, len(variant.Questions))
+	for _, q := range variant.Questions {
+		question, err := l.questionRepo.QuestionGet(ctx, variant.OrganizationId, variant.Id, q.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, constants.ErrorQuestionNotFound
+			}
+			l.log.WithContext(ctx).ErrorF("LiveOpen-QuestionGet failed: %v", err)
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+
+	pin, err := l.reservePin()
+	if err != nil {
+		l.log.WithContext(ctx).ErrorF("LiveOpen-reservePin failed: %v", err)
+		return nil, err
+	}
+
+	session, err := l.repo.LiveAdd(ctx, variant.OrganizationId, variant.Id, host.ID, pin)
+	if err != nil {
+		l.mu.Lock()
+		delete(l.sessions, pin)
+		l.mu.Unlock()
+		l.log.WithContext(ctx).ErrorF("LiveOpen failed: %v", err)
+		return nil, err
+	}
+
+	session.QuestionSeconds = open.QuestionSeconds
+	if session.QuestionSeconds == 0 {
+		session.QuestionSeconds = liveQuestionSeconds
+	}
+
+	l.mu.Lock()
+	l.sessions[pin] = &liveSession{
+		session:      session,
+		tenantId:     variant.OrganizationId,
+		variant:      variant,
+		questions:    questions,
+		current:      -1,
+		participants: make(map[int]*liveParticipant),
+		idleSince:    time.Now(),
+	}
+	l.mu.Unlock()
+
+	return session, nil
+}
+
+func (l *Live) LiveJoin(ctx context.Context, pin string, user *entities.User) (*entities.LiveClient, error) {
+	ctx, span := tracing.Start(ctx, "service.LiveJoin")
+	defer span.End()
+
+	s := l.session(pin)
+	if s == nil || s.tenantId != user.OrganizationId {
+		return nil, constants.ErrorLiveNotFound
+	}
+	if user.ID != s.session.HostId {
+		if err := variantAccess(ctx, l.accessRepo, l.log, s.variant, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client := &entities.LiveClient{
+		Pin:      pin,
+		UserId:   user.ID,
+		Host:     user.ID == s.session.HostId,
+		Messages: make(chan *entities.LiveMessage, liveBuffer),
+	}
+
+	if client.Host {
+		s.drop(s.host)
+		s.host = client
+		s.idleSince = time.Time{}
+	} else {
+		p, ok := s.participants[user.ID]
+		if !ok {
+			p = &liveParticipant{userId: user.ID, login: user.Login, answered: make(map[int]bool)}
+			s.participants[user.ID] = p
+		}
+		s.drop(p.client)
+		p.client = client
+	}
+
+	s.send(client, &entities.LiveMessage{Type: entities.LiveMessageJoined, Data: s.session})
+	if s.open {
+		s.send(client, s.questionMessage())
+	}
+	s.broadcast(&entities.LiveMessage{Type: entities.LiveMessageParticipants, Data: s.logins()})
+
+	return client, nil
+}
+
+func (l *Live) LiveLeave(client *entities.LiveClient) {
+	s := l.session(client.Pin)
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.host == client {
+		s.drop(s.host)
+		s.host = nil
+		s.idleSince = time.Now()
+		return
+	}
+
+	if p, ok := s.participants[client.UserId]; ok && p.client == client {
+		s.drop(p.client)
+		p.client = nil
+		s.broadcast(&entities.LiveMessage{Type: entities.LiveMessageParticipants, Data: s.logins()})
+	}
+}
+
+func (l *Live) LiveAnswer(ctx context.Context, client *entities.LiveClient, answer *entities.LiveAnswer) (*entities.LiveAnswerResult, error) {
+	ctx, span := tracing.Start(ctx, "service.LiveAnswer")
+	defer span.End()
+
+	s := l.session(client.Pin)
+	if s == nil {
+		return nil, constants.ErrorLiveNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.participants[client.UserId]
+	if !ok {
+		return nil, constants.ErrorUserNotFound
+	}
+	if !s.open {
+		return nil, constants.ErrorLiveNoQuestion
+	}
+
+	question := s.questions[s.current]
+	if answer.QuestionId != question.Id {
+		return nil, constants.ErrorLiveWrongQuestion
+	}
+	if p.answered[question.Id] {
+		return nil, constants.ErrorQuestionAnswered
+	}
+
+	now := time.Now()
+	if now.After(s.deadline) {
+		return nil, constants.ErrorLiveTimeUp
+	}
+
+	selected := answer.Answers
+	if answer.Answer != "" {
+		selected = append([]string{answer.Answer}, selected...)
+	}
+	if !question.Multiple && len(selected) > 1 {
+		selected = selected[:1]
+	}
+
+	points, correct := score(question, selected)
+
+	var bonus float64
+	if points > 0 {
+		remaining := s.deadline.Sub(now).Seconds() / s.deadline.Sub(s.openedAt).Seconds()
+		bonus = math.Round(points*liveSpeedBonus*remaining*100) / 100
+	}
+
+	p.answered[question.Id] = true
+	p.score += points + bonus
+	if correct {
+		p.correct++
+	}
+
+	var answered int
+	for _, participant := range s.participants {
+		if participant.answered[question.Id] {
+			answered++
+		}
+	}
+	s.send(s.host, &entities.LiveMessage{
+		Type: entities.LiveMessageProgress,
+		Data: map[string]int{"answered": answered, "participants": len(s.participants)},
+	})
+
+	return &entities.LiveAnswerResult{QuestionId: question.Id, Correct: correct, Points: points, Bonus: bonus}, nil
+}
+
+// LiveNext moves the session one step forward: an open question is closed and
+// the leaderboard is pushed, otherwise the next question is opened for everyone.
+// Stepping past the last question finishes the session.
+func (l *Live) LiveNext(ctx context.Context, pin string, hostId int) error {
+	ctx, span := tracing.Start(ctx, "service.LiveNext")
+	defer span.End()
+
+	s, err := l.hostSession(pin, hostId)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.open {
+		s.open = false
+		s.broadcast(&entities.LiveMessage{Type: entities.LiveMessageLeaderboard, Data: s.leaderboard()})
+		return nil
+	}
+
+	if s.current+1 >= len(s.questions) {
+		return l.finish(ctx, s)
+	}
+
+	s.current++
+	s.open = true
+	s.openedAt = time.Now()
+	s.deadline = s.openedAt.Add(time.Duration(s.session.QuestionSeconds) * time.Second)
+	s.broadcast(s.questionMessage())
+
+	return nil
+}
+
+func (l *Live) LiveFinish(ctx context.Context, pin string, hostId int) error {
+	ctx, span := tracing.Start(ctx, "service.LiveFinish")
+	defer span.End()
+
+	s, err := l.hostSession(pin, hostId)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return l.finish(ctx, s)
+}
+
+// finish persists the final leaderboard and tears the session down. The caller holds s.mu.
+func (l *Live) finish(ctx context.Context, s *liveSession) error {
+	board := s.leaderboard()
+
+	if err := l.repo.LiveFinish(ctx, s.tenantId, s.session.Id, board); err != nil {
+		l.log.WithContext(ctx).ErrorF("LiveFinish failed: %v", err)
+		return err
+	}
+
+	s.open = false
+	s.broadcast(&entities.LiveMessage{Type: entities.LiveMessageFinished, Data: board})
+
+	s.drop(s.host)
+	s.host = nil
+	for _, p := range s.participants {
+		s.drop(p.client)
+		p.client = nil
+	}
+
+	l.mu.Lock()
+	delete(l.sessions, s.session.Pin)
+	l.mu.Unlock()
+
+	return nil
+}
+
+// LiveExpireIdle finishes the sessions whose host has been gone for liveIdle,
+// so an abandoned game doesn't stay in memory. It returns how many it finished.
+func (l *Live) LiveExpireIdle(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.LiveExpireIdle")
+	defer span.End()
+
+	l.mu.Lock()
+	sessions := make([]*liveSession, 0, len(l.sessions))
+	for _, s := range l.sessions {
+		// a nil session is a PIN reserved by LiveOpen in progress
+		if s != nil {
+			sessions = append(sessions, s)
+		}
+	}
+	l.mu.Unlock()
+
+	var expired int
+	for _, s := range sessions {
+		s.mu.Lock()
+		if !s.idleSince.IsZero() && time.Since(s.idleSince) >= liveIdle {
+			if err := l.finish(ctx, s); err != nil {
+				s.mu.Unlock()
+				return expired, err
+			}
+			expired++
+		}
+		s.mu.Unlock()
+	}
+
+	return expired, nil
+}
+
+func (l *Live) session(pin string) *liveSession {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.sessions[pin]
+}
+
+func (l *Live) hostSession(pin string, hostId int) (*liveSession, error) {
+	s := l.session(pin)
+	if s == nil {
+		return nil, constants.ErrorLiveNotFound
+	}
+	if s.session.HostId != hostId {
+		return nil, constants.ErrorLiveNotHost
+	}
+	return s, nil
+}
+
+// reservePin picks a free six digit PIN and holds it until the session is stored.
+func (l *Live) reservePin() (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for {
+		n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+		if err != nil {
+			return "", err
+		}
+
+		pin := fmt.Sprintf("%06d", n.Int64())
+		if _, taken := l.sessions[pin]; !taken {
+			l.sessions[pin] = nil
+			return pin, nil
+		}
+	}
+}
+
+// send never blocks the session: a client that stopped reading misses messages.
+func (s *liveSession) send(client *entities.LiveClient, message *entities.LiveMessage) {
+	if client == nil {
+		return
+	}
+
+	select {
+	case client.Messages <- message:
+	default:
+	}
+}
+
+func (s *liveSession) broadcast(message *entities.LiveMessage) {
+	s.send(s.host, message)
+	for _, p := range s.participants {
+		s.send(p.client, message)
+	}
+}
+
+func (s *liveSession) drop(client *entities.LiveClient) {
+	if client != nil {
+		close(client.Messages)
+	}
+}
+
+// questionMessage sends the current question as takers see it, the correct
+// answer sorted in among the other options.
+func (s *liveSession) questionMessage() *entities.LiveMessage {
+	return &entities.LiveMessage{
+		Type: entities.LiveMessageQuestion,
+		Data: &entities.LiveQuestion{
+			Index:    s.current + 1,
+			Total:    len(s.questions),
+			Question: takerQuestion(s.questions[s.current]),
+			Deadline: s.deadline,
+		},
+	}
+}
+
+func (s *liveSession) logins() []string {
+	logins := make([]string, 0, len(s.participants))
+	for _, p := range s.participants {
+		if p.client != nil {
+			logins = append(logins, p.login)
+		}
+	}
+	sort.Strings(logins)
+
+	return logins
+}
+
+func (s *liveSession) leaderboard() []*entities.LiveScore {
+	board := make([]*entities.LiveScore, 0, len(s.participants))
+	for _, p := range s.participants {
+		board = append(board, &entities.LiveScore{
+			UserId:         p.userId,
+			Login:          p.login,
+			Score:          math.Round(p.score*100) / 100,
+			CorrectAnswers: p.correct,
+		})
+	}
+
+	sort.Slice(board, func(i, j int) bool {
+		if board[i].Score != board[j].Score {
+			return board[i].Score > board[j].Score
+		}
+		if board[i].CorrectAnswers != board[j].CorrectAnswers {
+			return board[i].CorrectAnswers > board[j].CorrectAnswers
+		}
+		return board[i].Login < board[j].Login
+	})
+
+	for i, entry := range board {
+		entry.Rank = i + 1
+		if i > 0 && entry.Score == board[i-1].Score && entry.CorrectAnswers == board[i-1].CorrectAnswers {
+			entry.Rank = board[i-1].Rank
+		}
+	}
+
+	return board
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+)
+
+type Organizations struct {
+	repo repository.OrganizationsRepository
+
+	log logger.Logging
+}
+
+func NewOrganizations(repo repository.OrganizationsRepository, log logger.Logging) *Organizations {
+	return &Organizations{repo: repo, log: log}
+}
+
+func (o *Organizations) OrganizationAdd(ctx context.Context, organization *entities.Organization) (*entities.Organization, error) {
+	ctx, span := tracing.Start(ctx, "service.OrganizationAdd")
+	defer span.End()
+
+	inviteCode, err := randomCode(16)
+	if err != nil {
+		o.log.WithContext(ctx).ErrorF("OrganizationAdd-randomCode failed: %v", err)
+		return nil, err
+	}
+
+	created, err := o.repo.OrganizationAdd(ctx, organization.Name, inviteCode)
+	if err != nil {
+		if errors.Is(err, constants.ErrorOrganizationAlreadyExists) {
+			return nil, err
+		}
+		o.log.WithContext(ctx).ErrorF("OrganizationAdd failed: %v", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+func (o *Organizations) OrganizationGet(ctx context.Context, name string) (*entities.Organization, error) {
+	ctx, span := tracing.Start(ctx, "service.OrganizationGet")
+	defer span.End()
+
+	organization, err := o.repo.OrganizationGet(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorOrganizationNotFound
+		}
+		o.log.WithContext(ctx).ErrorF("OrganizationGet failed: %v", err)
+		return nil, err
+	}
+	return organization, nil
+}
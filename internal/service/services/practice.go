@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+type Practice struct {
+	repo         repository.PracticeRepository
+	questionRepo repository.QuestionsRepository
+	accessRepo   repository.AccessRepository
+
+	log logger.Logging
+}
+
+func NewPractice(
+	repo repository.PracticeRepository,
+	questionRepo repository.QuestionsRepository,
+	accessRepo repository.AccessRepository,
+	log logger.Logging) *Practice {
+	return &Practice{repo: repo, questionRepo: questionRepo, accessRepo: accessRepo, log: log}
+}
+
+func (p *Practice) PracticeStart(ctx context.Context, variant *entities.Variant, user *entities.User) (*entities.Practice, error) {
+	ctx, span := tracing.Start(ctx, "service.PracticeStart")
+	defer span.End()
+
+	if err := p.allowed(ctx, variant, user); err != nil {
+		return nil, err
+	}
+
+	practice, err := p.repo.PracticeStart(ctx, variant.OrganizationId, variant.Id, user.ID)
+	if err != nil {
+		p.log.WithContext(ctx).ErrorF("PracticeStart failed: %v", err)
+		return nil, err
+	}
+	return practice, nil
+}
+
+// PracticeAccept scores the answer like a graded one and tells right away
+// whether it was correct. Like the review, it shows the correct options only
+// with reveal_answers, and the explanation and the feedback of the picked
+// options only with reveal_explanations.
+func (p *Practice) PracticeAccept(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int, answer *entities.UserAnswer) (*entities.PracticeFeedback, error) {
+	ctx, span := tracing.Start(ctx, "service.PracticeAccept")
+	defer span.End()
+
+	if err := p.allowed(ctx, variant, user); err != nil {
+		return nil, err
+	}
+
+	practice, err := p.repo.PracticeGet(ctx, variant.OrganizationId, user.ID, variant.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorPracticeNotFound
+		}
+		p.log.WithContext(ctx).ErrorF("PracticeAccept-PracticeGet failed: %v", err)
+		return nil, err
+	}
+
+	question, err := p.questionRepo.QuestionGet(ctx, variant.OrganizationId, variant.Id, questionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorQuestionNotFound
+		}
+		p.log.WithContext(ctx).ErrorF("PracticeAccept-QuestionGet failed: %v", err)
+		return nil, err
+	}
+
+	selected := selectedAnswers(question, answer)
+	_, correct := score(question, selected)
+
+	if err := p.repo.PracticeAccept(ctx, variant.OrganizationId, practice.ID, questionId, selected, correct); err != nil {
+		if errors.Is(err, constants.ErrorPracticeNotFound) {
+			return nil, err
+		}
+		p.log.WithContext(ctx).ErrorF("PracticeAccept failed: %v", err)
+		return nil, err
+	}
+
+	feedback := &entities.PracticeFeedback{Correct: correct}
+	picked := make(map[string]bool, len(selected))
+	for _, option := range selected {
+		picked[option] = true
+	}
+	if variant.RevealAnswers {
+		feedback.Answers = append(feedback.Answers, question.Answer)
+	}
+	for _, option := range question.Answers {
+		if variant.RevealAnswers && question.Multiple && option.Correct {
+			feedback.Answers = append(feedback.Answers, option.Answer)
+		}
+		if variant.RevealExplanations && picked[option.Answer] && option.Feedback != "" {
+			feedback.Feedback = append(feedback.Feedback, option.Feedback)
+		}
+	}
+	if variant.RevealExplanations {
+		feedback.Explanation = question.Explanation
+	}
+
+	return feedback, nil
+}
+
+// allowed opens practice only on variants whose author turned it on, within
+// their schedule and, for a restricted one, to invited users. The author and
+// admins skip the invitation like they do for live sessions.
+func (p *Practice) allowed(ctx context.Context, variant *entities.Variant, user *entities.User) error {
+	if !variant.Practice {
+		return constants.ErrorPracticeDisabled
+	}
+	if err := variantWindow(variant, time.Now()); err != nil {
+		return err
+	}
+	if variantAuthor(variant, user) == nil {
+		return nil
+	}
+	return variantAccess(ctx, p.accessRepo, p.log, variant, user.ID)
+}
+
+// PracticeFinish finishes the open practice and counts its correct answers.
+func (p *Practice) PracticeFinish(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error) {
+	ctx, span := tracing.Start(ctx, "service.PracticeFinish")
+	defer span.End()
+
+	practice, err := p.repo.PracticeGet(ctx, tenantId, userId, variantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorPracticeNotFound
+		}
+		p.log.WithContext(ctx).ErrorF("PracticeFinish-PracticeGet failed: %v", err)
+		return nil, err
+	}
+
+	practice, err = p.repo.PracticeFinish(ctx, tenantId, practice.ID)
+	if err != nil {
+		p.log.WithContext(ctx).ErrorF("PracticeFinish failed: %v", err)
+		return nil, err
+	}
+
+	return practice, nil
+}
+
+// PracticeResults shows the latest practice as it is, so it can be asked for
+// any number of times.
+func (p *Practice) PracticeResults(ctx context.Context, tenantId, variantId, userId int) (*entities.Practice, error) {
+	ctx, span := tracing.Start(ctx, "service.PracticeResults")
+	defer span.End()
+
+	practice, err := p.repo.PracticeResults(ctx, tenantId, userId, variantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorPracticeNotFound
+		}
+		p.log.WithContext(ctx).ErrorF("PracticeResults failed: %v", err)
+		return nil, err
+	}
+
+	return practice, nil
+}
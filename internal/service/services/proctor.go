@@ -0,0 +1,18 @@
+package service
+
+import (
+	"quiz-service/internal/entities"
+	"quiz-service/internal/events"
+)
+
+type Proctor struct {
+	broker *events.Broker
+}
+
+func NewProctor(broker *events.Broker) *Proctor {
+	return &Proctor{broker: broker}
+}
+
+func (p *Proctor) ProctorSubscribe(variantId int, lastEventId int64) ([]*entities.ProctorEvent, <-chan *entities.ProctorEvent, func()) {
+	return p.broker.Subscribe(variantId, lastEventId)
+}
@@ -5,11 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"quiz-service/init/logger"
-	"strings"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"quiz-service/internal/entities"
+	"quiz-service/internal/events"
+	"quiz-service/internal/metrics"
 	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
 	"quiz-service/pkg/constants"
 )
 
@@ -18,6 +23,8 @@ type Questions struct {
 	variantRepo  repository.VariantRepository
 	testingRepo  repository.TestingRepository
 
+	publisher events.Publisher
+
 	log logger.Logging
 
 	mu sync.Mutex
@@ -27,18 +34,23 @@ func NewQuestions(
 	questionRepo repository.QuestionsRepository,
 	variantRepo repository.VariantRepository,
 	testingRepo repository.TestingRepository,
+	publisher events.Publisher,
 	log logger.Logging) *Questions {
 	return &Questions{
 		questionRepo: questionRepo,
 		variantRepo:  variantRepo,
 		testingRepo:  testingRepo,
+		publisher:    publisher,
 		log:          log,
 		mu:           sync.Mutex{},
 	}
 }
 
-func (q *Questions) QuestionAdd(ctx context.Context, variantId int, question *entities.Question) error {
-	count, err := q.questionRepo.QuestionCount(ctx, variantId)
+func (q *Questions) QuestionAdd(ctx context.Context, tenantId, variantId int, question *entities.Question) error {
+	ctx, span := tracing.Start(ctx, "service.QuestionAdd")
+	defer span.End()
+
+	count, err := q.questionRepo.QuestionCount(ctx, tenantId, variantId)
 	if err != nil {
 		return err
 	}
@@ -47,20 +59,36 @@ func (q *Questions) QuestionAdd(ctx context.Context, variantId int, question *en
 		return constants.ErrorQuestionLimitExceeded
 	}
 
-	if err := q.questionRepo.QuestionAdd(ctx, variantId, question); err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return constants.ErrorQuestionAlreadyExists
+	if !question.Multiple {
+		for _, answer := range question.Answers {
+			if answer.Correct {
+				return constants.ErrorQuestionNotMultiple
+			}
+		}
+	}
+
+	if question.Points == nil {
+		points := defaultPoints
+		question.Points = &points
+	}
+
+	if err := q.questionRepo.QuestionAdd(ctx, tenantId, variantId, question); err != nil {
+		if errors.Is(err, constants.ErrorQuestionAlreadyExists) {
+			return err
 		}
-		q.log.ErrorF("QuestionAdd failed: %v", err)
+		q.log.WithContext(ctx).ErrorF("QuestionAdd failed: %v", err)
 		return err
 	}
 	return nil
 }
 
-func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question *entities.QuestionRemove) error {
-	num, err := q.questionRepo.QuestionRemove(ctx, variantId, question.Question)
+func (q *Questions) QuestionRemove(ctx context.Context, tenantId, variantId int, question *entities.QuestionRemove) error {
+	ctx, span := tracing.Start(ctx, "service.QuestionRemove")
+	defer span.End()
+
+	num, err := q.questionRepo.QuestionRemove(ctx, tenantId, variantId, question.Question)
 	if err != nil {
-		q.log.ErrorF("QuestionRemove failed: %v", err)
+		q.log.WithContext(ctx).ErrorF("QuestionRemove failed: %v", err)
 		return err
 	}
 	if num == 0 {
@@ -70,35 +98,144 @@ func (q *Questions) QuestionRemove(ctx context.Context, variantId int, question
 	return nil
 }
 
-func (q *Questions) QuestionGet(ctx context.Context, variantId, questionId int) (*entities.Question, error) {
-	questions, err := q.questionRepo.QuestionGet(ctx, variantId, questionId)
+func (q *Questions) QuestionGet(ctx context.Context, tenantId, variantId, questionId int) (*entities.Question, error) {
+	ctx, span := tracing.Start(ctx, "service.QuestionGet")
+	defer span.End()
+
+	questions, err := q.questionRepo.QuestionGet(ctx, tenantId, variantId, questionId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, constants.ErrorQuestionNotFound
 		}
-		q.log.ErrorF("QuestionGet failed: %v", err)
+		q.log.WithContext(ctx).ErrorF("QuestionGet failed: %v", err)
 		return nil, err
 	}
 	return questions, nil
 }
 
-func (q *Questions) QuestionAccept(ctx context.Context, variantId, userId int, answer string) error {
-	test, err := q.testingRepo.TestGet(ctx, userId, variantId)
+// QuestionView returns the question as the variant's author sees it, or a copy
+// fit for a taker.
+func (q *Questions) QuestionView(variant *entities.Variant, user *entities.User, question *entities.Question) *entities.Question {
+	if variantAuthor(variant, user) == nil {
+		return question
+	}
+	return takerQuestion(question)
+}
+
+// takerQuestion copies the question without anything that gives it away: the
+// correct answer is merged into the sorted options, and the explanation, hints
+// and the flags and feedback of the options are dropped.
+func takerQuestion(question *entities.Question) *entities.Question {
+	options := make([]string, 0, len(question.Answers)+1)
+	if question.Answer != "" {
+		options = append(options, question.Answer)
+	}
+	for _, answer := range question.Answers {
+		options = append(options, answer.Answer)
+	}
+	sort.Strings(options)
+
+	answers := make([]*entities.Answer, 0, len(options))
+	for _, option := range options {
+		answers = append(answers, &entities.Answer{Answer: option})
+	}
+
+	return &entities.Question{
+		Id:             question.Id,
+		Question:       question.Question,
+		Points:         question.Points,
+		NegativePoints: question.NegativePoints,
+		Multiple:       question.Multiple,
+		Answers:        answers,
+	}
+}
+
+func (q *Questions) QuestionAccept(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int, answer *entities.UserAnswer) error {
+	ctx, span := tracing.Start(ctx, "service.QuestionAccept")
+	defer span.End()
+
+	if err := variantWindow(variant, time.Now()); err != nil {
+		return err
+	}
+
+	test, err := q.testingRepo.TestGet(ctx, variant.OrganizationId, user.ID, variant.Id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return constants.ErrorTestNotFound
 		}
-		q.log.ErrorF("QuestionAccept-TestGet failed: %v", err)
+		q.log.WithContext(ctx).ErrorF("QuestionAccept-TestGet failed: %v", err)
 		return err
 	}
 
-	if err := q.questionRepo.QuestionAccept(ctx, answer, userId, test.ID, variantId); err != nil {
+	if test.FinishAt != nil {
+		return constants.ErrorVariantCompleted
+	}
+
+	question, err := q.questionRepo.QuestionGet(ctx, variant.OrganizationId, variant.Id, questionId)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return constants.ErrorQuestionNotFound
 		}
-		q.log.ErrorF("QuestionAccept failed: %v", err)
+		q.log.WithContext(ctx).ErrorF("QuestionAccept-QuestionGet failed: %v", err)
 		return err
 	}
 
+	selected := selectedAnswers(question, answer)
+	points, correct := score(question, selected)
+
+	if err := q.questionRepo.QuestionAccept(ctx, variant.OrganizationId, test.ID, questionId, selected, points, correct); err != nil {
+		if errors.Is(err, constants.ErrorQuestionAnswered) {
+			return err
+		}
+		q.log.WithContext(ctx).ErrorF("QuestionAccept failed: %v", err)
+		return err
+	}
+	metrics.AnswersAccepted.WithLabelValues(strconv.FormatBool(correct)).Inc()
+
+	q.publisher.Publish(&entities.ProctorEvent{
+		Type:       entities.ProctorAnswered,
+		VariantId:  variant.Id,
+		UserId:     user.ID,
+		Login:      user.Login,
+		QuestionId: questionId,
+		At:         time.Now(),
+	})
+
 	return nil
 }
+
+func (q *Questions) QuestionHint(ctx context.Context, variant *entities.Variant, user *entities.User, questionId int) (*entities.Hint, error) {
+	ctx, span := tracing.Start(ctx, "service.QuestionHint")
+	defer span.End()
+
+	if err := variantWindow(variant, time.Now()); err != nil {
+		return nil, err
+	}
+
+	test, err := q.testingRepo.TestGet(ctx, variant.OrganizationId, user.ID, variant.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorTestNotFound
+		}
+		q.log.WithContext(ctx).ErrorF("QuestionHint-TestGet failed: %v", err)
+		return nil, err
+	}
+
+	if test.FinishAt != nil {
+		return nil, constants.ErrorVariantCompleted
+	}
+
+	hint, err := q.questionRepo.QuestionHint(ctx, variant.OrganizationId, test.ID, variant.Id, questionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorQuestionNotFound
+		}
+		if errors.Is(err, constants.ErrorNoHintsLeft) {
+			return nil, err
+		}
+		q.log.WithContext(ctx).ErrorF("QuestionHint failed: %v", err)
+		return nil, err
+	}
+
+	return hint, nil
+}
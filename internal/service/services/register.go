@@ -5,44 +5,95 @@ import (
 	"database/sql"
 	"errors"
 	"quiz-service/init/logger"
+	"quiz-service/internal/tracing"
 	"quiz-service/pkg/constants"
-	"strings"
 
 	"github.com/google/uuid"
 
 	"quiz-service/internal/entities"
+	"quiz-service/internal/metrics"
 	"quiz-service/internal/repository"
 	"quiz-service/pkg/hash"
 )
 
 type Register struct {
-	repo repository.RegisterRepository
+	repo             repository.RegisterRepository
+	organizationRepo repository.OrganizationsRepository
 
 	log logger.Logging
 
 	hasher hash.Hasher
 }
 
-func NewRegister(repo repository.RegisterRepository, hasher hash.Hasher, log logger.Logging) *Register {
-	return &Register{repo: repo, hasher: hasher, log: log}
+func NewRegister(
+	repo repository.RegisterRepository,
+	organizationRepo repository.OrganizationsRepository,
+	hasher hash.Hasher,
+	log logger.Logging) *Register {
+	return &Register{repo: repo, organizationRepo: organizationRepo, hasher: hasher, log: log}
 }
 
 func (r *Register) Register(ctx context.Context, register *entities.Register) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "service.Register")
+	defer span.End()
+
+	organization, err := r.organization(ctx, register)
+	if err != nil {
+		return nil, err
+	}
+
+	register.OrganizationId = organization.Id
 	register.UUID = uuid.NewString()
 	register.Password = r.hasher.Hash(register.Password)
 
 	user, err := r.repo.Register(ctx, register)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return nil, constants.ErrorUserAlreadyExists
+		if errors.Is(err, constants.ErrorUserAlreadyExists) {
+			return nil, err
 		}
-		r.log.ErrorF("Register failed: %v", err)
+		r.log.WithContext(ctx).ErrorF("Register failed: %v", err)
 		return nil, err
 	}
+	metrics.Registrations.Inc()
+
 	return user, nil
 }
 
+// organization binds the new user by the invite code, which clients send, or by
+// the name, which only the CLI sets. Without either the user joins the
+// default organization.
+func (r *Register) organization(ctx context.Context, register *entities.Register) (*entities.Organization, error) {
+	if register.Invite != "" {
+		organization, err := r.organizationRepo.OrganizationByInvite(ctx, register.Invite)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, constants.ErrorInviteNotFound
+			}
+			r.log.WithContext(ctx).ErrorF("Register-OrganizationByInvite failed: %v", err)
+			return nil, err
+		}
+		return organization, nil
+	}
+
+	if register.Organization == "" {
+		register.Organization = entities.DefaultOrganization
+	}
+
+	organization, err := r.organizationRepo.OrganizationGet(ctx, register.Organization)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorOrganizationNotFound
+		}
+		r.log.WithContext(ctx).ErrorF("Register-OrganizationGet failed: %v", err)
+		return nil, err
+	}
+	return organization, nil
+}
+
 func (r *Register) Login(ctx context.Context, login *entities.Login) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "service.Login")
+	defer span.End()
+
 	login.Password = r.hasher.Hash(login.Password)
 
 	user, err := r.repo.Login(ctx, login)
@@ -50,7 +101,7 @@ func (r *Register) Login(ctx context.Context, login *entities.Login) (*entities.
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, constants.ErrorUserNotFound
 		}
-		r.log.ErrorF("Login failed: %v", err)
+		r.log.WithContext(ctx).ErrorF("Login failed: %v", err)
 		return nil, err
 	}
 
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+	"time"
+)
+
+func variantStatus(variant *entities.Variant, now time.Time) string {
+	switch {
+	case variant.OpensAt != nil && now.Before(*variant.OpensAt):
+		return entities.VariantUpcoming
+	case variant.ClosesAt != nil && !now.Before(*variant.ClosesAt):
+		return entities.VariantClosed
+	default:
+		return entities.VariantOpen
+	}
+}
+
+func variantWindow(variant *entities.Variant, now time.Time) error {
+	switch variantStatus(variant, now) {
+	case entities.VariantUpcoming:
+		return constants.ErrorVariantNotOpen
+	case entities.VariantClosed:
+		return constants.ErrorVariantClosed
+	}
+	return nil
+}
+
+func validSchedule(opensAt, closesAt *time.Time) bool {
+	return opensAt == nil || closesAt == nil || closesAt.After(*opensAt)
+}
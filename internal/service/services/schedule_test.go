@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"quiz-service/internal/entities"
+	"quiz-service/pkg/constants"
+)
+
+func TestVariantSchedule(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		moment := now.Add(d)
+		return &moment
+	}
+
+	tests := []struct {
+		name     string
+		opensAt  *time.Time
+		closesAt *time.Time
+		status   string
+		err      error
+	}{
+		{"no bounds", nil, nil, entities.VariantOpen, nil},
+		{"opens later", at(time.Second), nil, entities.VariantUpcoming, constants.ErrorVariantNotOpen},
+		{"opens now", at(0), nil, entities.VariantOpen, nil},
+		{"opened before", at(-time.Hour), nil, entities.VariantOpen, nil},
+		{"closes later", nil, at(time.Second), entities.VariantOpen, nil},
+		{"closes now", nil, at(0), entities.VariantClosed, constants.ErrorVariantClosed},
+		{"closed before", nil, at(-time.Second), entities.VariantClosed, constants.ErrorVariantClosed},
+		{"inside window", at(-time.Hour), at(time.Hour), entities.VariantOpen, nil},
+		{"before window", at(time.Hour), at(2 * time.Hour), entities.VariantUpcoming, constants.ErrorVariantNotOpen},
+		{"after window", at(-2 * time.Hour), at(-time.Hour), entities.VariantClosed, constants.ErrorVariantClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variant := &entities.Variant{OpensAt: tt.opensAt, ClosesAt: tt.closesAt}
+
+			if status := variantStatus(variant, now); status != tt.status {
+				t.Errorf("variantStatus() = %q; want %q", status, tt.status)
+			}
+			if err := variantWindow(variant, now); !errors.Is(err, tt.err) {
+				t.Errorf("variantWindow() = %v; want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidSchedule(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		moment := now.Add(d)
+		return &moment
+	}
+
+	tests := []struct {
+		name     string
+		opensAt  *time.Time
+		closesAt *time.Time
+		valid    bool
+	}{
+		{"no bounds", nil, nil, true},
+		{"only opens", at(0), nil, true},
+		{"only closes", nil, at(0), true},
+		{"closes after opens", at(0), at(time.Second), true},
+		{"closes when opens", at(0), at(0), false},
+		{"opens after closes", at(time.Second), at(0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := validSchedule(tt.opensAt, tt.closesAt); valid != tt.valid {
+				t.Errorf("validSchedule() = %v; want %v", valid, tt.valid)
+			}
+		})
+	}
+}
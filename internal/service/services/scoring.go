@@ -0,0 +1,64 @@
+package service
+
+import "quiz-service/internal/entities"
+
+// defaultPoints is what a question is worth when it is added without points;
+// an explicit zero is kept, so a question can count for nothing.
+const defaultPoints = 1.0
+
+// score returns the points awarded for the selected options and whether the
+// answer is fully correct. Multiple choice questions get partial credit for
+// every correct option minus every wrong one; a non-positive result with any
+// wrong option selected falls back to the negative marking.
+func score(question *entities.Question, selected []string) (float64, bool) {
+	correct := map[string]bool{question.Answer: true}
+	if question.Multiple {
+		for _, answer := range question.Answers {
+			if answer.Correct {
+				correct[answer.Answer] = true
+			}
+		}
+	}
+
+	var right, wrong int
+	seen := make(map[string]bool, len(selected))
+	for _, answer := range selected {
+		if seen[answer] {
+			continue
+		}
+		seen[answer] = true
+
+		if correct[answer] {
+			right++
+		} else {
+			wrong++
+		}
+	}
+
+	if right == len(correct) && wrong == 0 {
+		return *question.Points, true
+	}
+
+	ratio := float64(right-wrong) / float64(len(correct))
+	if question.Multiple && ratio > 0 {
+		return *question.Points * ratio, false
+	}
+	if wrong > 0 {
+		return -question.NegativePoints, false
+	}
+
+	return 0, false
+}
+
+// selectedAnswers joins the single answer and the list into the options the
+// user picked; a question without multiple choice keeps only the first.
+func selectedAnswers(question *entities.Question, answer *entities.UserAnswer) []string {
+	selected := answer.Answers
+	if answer.Answer != "" {
+		selected = append([]string{answer.Answer}, selected...)
+	}
+	if !question.Multiple && len(selected) > 1 {
+		selected = selected[:1]
+	}
+	return selected
+}
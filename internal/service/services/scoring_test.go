@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"quiz-service/internal/entities"
+)
+
+func TestScore(t *testing.T) {
+	points := func(v float64) *float64 { return &v }
+
+	single := &entities.Question{
+		Answer:         "a",
+		Points:         points(2),
+		NegativePoints: 0.5,
+		Answers:        []*entities.Answer{{Answer: "b"}, {Answer: "c"}, {Answer: "d", Correct: true}},
+	}
+	multiple := &entities.Question{
+		Answer:         "a",
+		Points:         points(3),
+		NegativePoints: 1,
+		Multiple:       true,
+		Answers:        []*entities.Answer{{Answer: "b", Correct: true}, {Answer: "c"}, {Answer: "d"}},
+	}
+	unmarked := &entities.Question{
+		Answer:   "a",
+		Points:   points(3),
+		Multiple: true,
+		Answers:  []*entities.Answer{{Answer: "b", Correct: true}, {Answer: "c"}, {Answer: "d"}},
+	}
+	free := &entities.Question{
+		Answer:  "a",
+		Points:  points(0),
+		Answers: []*entities.Answer{{Answer: "b"}, {Answer: "c"}, {Answer: "d"}},
+	}
+
+	tests := []struct {
+		name     string
+		question *entities.Question
+		selected []string
+		points   float64
+		correct  bool
+	}{
+		{"single right", single, []string{"a"}, 2, true},
+		{"single right twice", single, []string{"a", "a"}, 2, true},
+		{"single wrong", single, []string{"b"}, -0.5, false},
+		{"single ignores correct flags", single, []string{"d"}, -0.5, false},
+		{"single right and wrong", single, []string{"a", "b"}, -0.5, false},
+		{"single nothing", single, nil, 0, false},
+		{"multiple all right", multiple, []string{"a", "b"}, 3, true},
+		{"multiple half right", multiple, []string{"b"}, 1.5, false},
+		{"multiple all right and one wrong", multiple, []string{"a", "b", "c"}, 1.5, false},
+		{"multiple as many wrong as right", multiple, []string{"a", "c"}, -1, false},
+		{"multiple only wrong", multiple, []string{"c", "d"}, -1, false},
+		{"multiple nothing", multiple, nil, 0, false},
+		{"clamped without negative marking", unmarked, []string{"c", "d"}, 0, false},
+		{"clamped partial credit", unmarked, []string{"a", "c", "d"}, 0, false},
+		{"zero points", free, []string{"a"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, correct := score(tt.question, tt.selected)
+			if points != tt.points || correct != tt.correct {
+				t.Errorf("score(%v) = %v, %v; want %v, %v", tt.selected, points, correct, tt.points, tt.correct)
+			}
+		})
+	}
+}
@@ -7,23 +7,30 @@ import (
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
 	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
 	"quiz-service/pkg/constants"
+	"quiz-service/pkg/hash"
 )
 
 type User struct {
 	repo repository.UserRepository
 
 	log logger.Logging
+
+	hasher hash.Hasher
 }
 
-func NewUser(repo repository.UserRepository, log logger.Logging) *User {
-	return &User{repo: repo, log: log}
+func NewUser(repo repository.UserRepository, hasher hash.Hasher, log logger.Logging) *User {
+	return &User{repo: repo, hasher: hasher, log: log}
 }
 
 func (u *User) Quit(ctx context.Context, uuid string) error {
+	ctx, span := tracing.Start(ctx, "service.Quit")
+	defer span.End()
+
 	rowsAffected, err := u.repo.Quit(ctx, uuid)
 	if err != nil {
-		u.log.ErrorF("Quit failed: %v", err)
+		u.log.WithContext(ctx).ErrorF("Quit failed: %v", err)
 		return err
 	}
 	if rowsAffected == 0 {
@@ -34,13 +41,76 @@ func (u *User) Quit(ctx context.Context, uuid string) error {
 }
 
 func (u *User) Authenticated(ctx context.Context, uuid string) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "service.Authenticated")
+	defer span.End()
+
 	user, err := u.repo.Authenticated(ctx, uuid)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, constants.ErrorUserNotFound
 		}
-		u.log.ErrorF("Authenticated failed: %v", err)
+		u.log.WithContext(ctx).ErrorF("Authenticated failed: %v", err)
 		return nil, err
 	}
 	return user, nil
 }
+
+// UserAdmin allows only the organization's admins through.
+func (u *User) UserAdmin(user *entities.User) error {
+	if user.Role != entities.RoleAdmin {
+		return constants.ErrorUserNotAdmin
+	}
+	return nil
+}
+
+func (u *User) UserPasswordReset(ctx context.Context, login, password string) error {
+	ctx, span := tracing.Start(ctx, "service.UserPasswordReset")
+	defer span.End()
+
+	rowsAffected, err := u.repo.UserPasswordSet(ctx, login, u.hasher.Hash(password))
+	if err != nil {
+		u.log.WithContext(ctx).ErrorF("UserPasswordReset failed: %v", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return constants.ErrorUserNotFound
+	}
+
+	return nil
+}
+
+func (u *User) UserRoleSet(ctx context.Context, login, role string) error {
+	ctx, span := tracing.Start(ctx, "service.UserRoleSet")
+	defer span.End()
+
+	if role != entities.RoleUser && role != entities.RoleAdmin {
+		return constants.ErrorUserRoleInvalid
+	}
+
+	rowsAffected, err := u.repo.UserRoleSet(ctx, login, role)
+	if err != nil {
+		u.log.WithContext(ctx).ErrorF("UserRoleSet failed: %v", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return constants.ErrorUserNotFound
+	}
+
+	return nil
+}
+
+func (u *User) UserDisable(ctx context.Context, login string) error {
+	ctx, span := tracing.Start(ctx, "service.UserDisable")
+	defer span.End()
+
+	rowsAffected, err := u.repo.UserDisable(ctx, login)
+	if err != nil {
+		u.log.WithContext(ctx).ErrorF("UserDisable failed: %v", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return constants.ErrorUserNotFound
+	}
+
+	return nil
+}
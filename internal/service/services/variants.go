@@ -6,40 +6,165 @@ import (
 	"errors"
 	"quiz-service/init/logger"
 	"quiz-service/internal/entities"
+	"quiz-service/internal/events"
+	"quiz-service/internal/metrics"
 	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
 	"quiz-service/pkg/constants"
-	"strings"
+	"time"
 )
 
 type Variant struct {
-	repo repository.VariantRepository
+	repo            repository.VariantRepository
+	testingRepo     repository.TestingRepository
+	certificateRepo repository.CertificateRepository
+	accessRepo      repository.AccessRepository
+
+	publisher events.Publisher
 
 	log logger.Logging
 }
 
-func NewVariant(repo repository.VariantRepository, log logger.Logging) *Variant {
-	return &Variant{repo: repo, log: log}
+func NewVariant(
+	repo repository.VariantRepository,
+	testingRepo repository.TestingRepository,
+	certificateRepo repository.CertificateRepository,
+	accessRepo repository.AccessRepository,
+	publisher events.Publisher,
+	log logger.Logging) *Variant {
+	return &Variant{
+		repo:            repo,
+		testingRepo:     testingRepo,
+		certificateRepo: certificateRepo,
+		accessRepo:      accessRepo,
+		publisher:       publisher,
+		log:             log,
+	}
 }
 
-func (v *Variant) VariantAdd(ctx context.Context, name string) error {
-	if err := v.repo.VariantAdd(ctx, name); err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return constants.ErrorVariantAlreadyExists
+// VariantAdd records authorId as the author; zero leaves the variant without
+// one, to be managed by admins only.
+func (v *Variant) VariantAdd(ctx context.Context, tenantId, authorId int, variant *entities.Variant) error {
+	ctx, span := tracing.Start(ctx, "service.VariantAdd")
+	defer span.End()
+
+	if !validSchedule(variant.OpensAt, variant.ClosesAt) {
+		return constants.ErrorVariantSchedule
+	}
+
+	variant.AuthorId = nil
+	if authorId != 0 {
+		variant.AuthorId = &authorId
+	}
+
+	if err := v.repo.VariantAdd(ctx, tenantId, variant); err != nil {
+		if errors.Is(err, constants.ErrorVariantAlreadyExists) || errors.Is(err, constants.ErrorVariantTooLong) {
+			return err
 		}
-		if strings.Contains(err.Error(), "value too long for type character") {
-			return constants.ErrorVariantTooLong
+		v.log.WithContext(ctx).ErrorF("VariantAdd failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// VariantAuthor allows the variant's author and the organization's admins to
+// change the variant, its questions and who may take it.
+func (v *Variant) VariantAuthor(variant *entities.Variant, user *entities.User) error {
+	return variantAuthor(variant, user)
+}
+
+// VariantAccess lets the user read the variant and its questions: a restricted
+// variant is shown only to invited users, its author and admins.
+func (v *Variant) VariantAccess(ctx context.Context, variant *entities.Variant, user *entities.User) error {
+	if variantAuthor(variant, user) == nil {
+		return nil
+	}
+	return variantAccess(ctx, v.accessRepo, v.log, variant, user.ID)
+}
+
+// VariantAllowed keeps the variants the user may read, as VariantAccess decides.
+func (v *Variant) VariantAllowed(ctx context.Context, variants []*entities.Variant, user *entities.User) ([]*entities.Variant, error) {
+	allowed := make([]*entities.Variant, 0, len(variants))
+	for _, variant := range variants {
+		if err := v.VariantAccess(ctx, variant, user); err != nil {
+			if errors.Is(err, constants.ErrorVariantRestricted) {
+				continue
+			}
+			return nil, err
 		}
-		v.log.ErrorF("VariantAdd failed: %v", err)
+		allowed = append(allowed, variant)
+	}
+	return allowed, nil
+}
+
+// variantAccess lets a user take a restricted variant only when invited to it,
+// directly or through a group.
+func variantAccess(ctx context.Context, accessRepo repository.AccessRepository, log logger.Logging, variant *entities.Variant, userId int) error {
+	if !variant.Restricted {
+		return nil
+	}
+
+	allowed, err := accessRepo.AccessAllowed(ctx, variant.OrganizationId, variant.Id, userId)
+	if err != nil {
+		log.WithContext(ctx).ErrorF("AccessAllowed failed: %v", err)
+		return err
+	}
+	if !allowed {
+		return constants.ErrorVariantRestricted
+	}
+	return nil
+}
+
+func variantAuthor(variant *entities.Variant, user *entities.User) error {
+	if user.Role == entities.RoleAdmin || (variant.AuthorId != nil && *variant.AuthorId == user.ID) {
+		return nil
+	}
+	return constants.ErrorVariantNotAuthor
+}
+
+// VariantView returns the variant as its author sees it, or a copy whose
+// questions give nothing away to everyone else.
+func (v *Variant) VariantView(variant *entities.Variant, user *entities.User) *entities.Variant {
+	if variantAuthor(variant, user) == nil {
+		return variant
+	}
+
+	view := *variant
+	view.Questions = make([]*entities.Question, 0, len(variant.Questions))
+	for _, question := range variant.Questions {
+		view.Questions = append(view.Questions, takerQuestion(question))
+	}
+	return &view
+}
+
+func (v *Variant) VariantSettings(ctx context.Context, tenantId, variantId int, settings *entities.VariantSettings) error {
+	ctx, span := tracing.Start(ctx, "service.VariantSettings")
+	defer span.End()
+
+	if !validSchedule(settings.OpensAt, settings.ClosesAt) {
+		return constants.ErrorVariantSchedule
+	}
+
+	num, err := v.repo.VariantSettings(ctx, tenantId, variantId, settings)
+	if err != nil {
+		v.log.WithContext(ctx).ErrorF("VariantSettings failed: %v", err)
 		return err
 	}
+	if num == 0 {
+		return constants.ErrorVariantNotFound
+	}
 
 	return nil
 }
 
-func (v *Variant) VariantRemove(ctx context.Context, name string) error {
-	num, err := v.repo.VariantRemove(ctx, name)
+func (v *Variant) VariantRemove(ctx context.Context, tenantId int, name string) error {
+	ctx, span := tracing.Start(ctx, "service.VariantRemove")
+	defer span.End()
+
+	num, err := v.repo.VariantRemove(ctx, tenantId, name)
 	if err != nil {
-		v.log.ErrorF("VariantRemove failed: %v", err)
+		v.log.WithContext(ctx).ErrorF("VariantRemove failed: %v", err)
 		return err
 	}
 	if num == 0 {
@@ -49,10 +174,13 @@ func (v *Variant) VariantRemove(ctx context.Context, name string) error {
 	return nil
 }
 
-func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error) {
-	variants, err := v.repo.VariantList(ctx)
+func (v *Variant) VariantList(ctx context.Context, tenantId int) ([]*entities.Variant, error) {
+	ctx, span := tracing.Start(ctx, "service.VariantList")
+	defer span.End()
+
+	variants, err := v.repo.VariantList(ctx, tenantId)
 	if err != nil {
-		v.log.ErrorF("VariantList failed: %v", err)
+		v.log.WithContext(ctx).ErrorF("VariantList failed: %v", err)
 		return nil, err
 	}
 
@@ -60,37 +188,170 @@ func (v *Variant) VariantList(ctx context.Context) ([]*entities.Variant, error)
 		return nil, constants.ErrorNoVariantsYet
 	}
 
+	now := time.Now()
+	for _, variant := range variants {
+		variant.Status = variantStatus(variant, now)
+	}
+
 	return variants, nil
 }
 
-func (v *Variant) VariantGet(ctx context.Context, variantName string) (*entities.Variant, error) {
-	variant, err := v.repo.VariantGet(ctx, variantName)
+func (v *Variant) VariantGet(ctx context.Context, tenantId int, variantName string) (*entities.Variant, error) {
+	ctx, span := tracing.Start(ctx, "service.VariantGet")
+	defer span.End()
+
+	variant, err := v.repo.VariantGet(ctx, tenantId, variantName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, constants.ErrorVariantNotFound
 		}
-		v.log.ErrorF("VariantGet failed: %v", err)
+		v.log.WithContext(ctx).ErrorF("VariantGet failed: %v", err)
 		return nil, err
 	}
+
+	variant.Status = variantStatus(variant, time.Now())
+
 	return variant, nil
 }
 
-func (v *Variant) VariantStart(ctx context.Context, variantId, userId int) error {
-	if err := v.repo.VariantStart(ctx, variantId, userId); err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+func (v *Variant) VariantStart(ctx context.Context, variant *entities.Variant, user *entities.User) error {
+	ctx, span := tracing.Start(ctx, "service.VariantStart")
+	defer span.End()
+
+	if err := variantWindow(variant, time.Now()); err != nil {
+		return err
+	}
+
+	if err := variantAccess(ctx, v.accessRepo, v.log, variant, user.ID); err != nil {
+		return err
+	}
+
+	if err := v.repo.VariantStart(ctx, variant.OrganizationId, variant.Id, user.ID); err != nil {
+		if errors.Is(err, constants.ErrorTestAlreadyStarted) {
 			return nil
 		}
-		v.log.ErrorF("VariantStart failed: %v", err)
+		v.log.WithContext(ctx).ErrorF("VariantStart failed: %v", err)
 		return err
 	}
+	metrics.AttemptsStarted.Inc()
+
+	v.publisher.Publish(&entities.ProctorEvent{
+		Type:      entities.ProctorStarted,
+		VariantId: variant.Id,
+		UserId:    user.ID,
+		Login:     user.Login,
+		At:        time.Now(),
+	})
+
 	return nil
 }
 
-func (v *Variant) VariantResults(ctx context.Context, variantId, userId int) (*entities.Testing, error) {
-	testing, err := v.repo.VariantResults(ctx, variantId, userId)
+func (v *Variant) VariantResults(ctx context.Context, variant *entities.Variant, user *entities.User) (*entities.Testing, error) {
+	ctx, span := tracing.Start(ctx, "service.VariantResults")
+	defer span.End()
+
+	testing, finished, err := v.repo.VariantResults(ctx, variant.OrganizationId, variant.Id, user.ID)
 	if err != nil {
-		v.log.ErrorF("VariantResults failed: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorTestNotFound
+		}
+		v.log.WithContext(ctx).ErrorF("VariantResults failed: %v", err)
 		return nil, err
 	}
+
+	testing.Passed = variant.PassMark > 0 && testing.MaxScore > 0 && testing.Score*100/testing.MaxScore >= variant.PassMark
+
+	// Only the first finish counts; asking again just shows the results.
+	if !finished {
+		return testing, nil
+	}
+
+	metrics.AttemptsFinished.Inc()
+	v.publisher.Publish(&entities.ProctorEvent{
+		Type:      entities.ProctorFinished,
+		VariantId: variant.Id,
+		UserId:    user.ID,
+		Login:     user.Login,
+		At:        time.Now(),
+	})
+
+	if testing.Passed {
+		code, err := randomCode(8)
+		if err != nil {
+			v.log.WithContext(ctx).ErrorF("VariantResults-randomCode failed: %v", err)
+			return nil, err
+		}
+		if _, err := v.certificateRepo.CertificateIssue(ctx, variant.OrganizationId, testing.ID, code); err != nil {
+			v.log.WithContext(ctx).ErrorF("VariantResults-CertificateIssue failed: %v", err)
+			return nil, err
+		}
+	}
+
 	return testing, nil
 }
+
+func (v *Variant) VariantReview(ctx context.Context, variant *entities.Variant, userId int) ([]*entities.Review, error) {
+	ctx, span := tracing.Start(ctx, "service.VariantReview")
+	defer span.End()
+
+	test, err := v.testingRepo.TestGet(ctx, variant.OrganizationId, userId, variant.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorTestNotFound
+		}
+		v.log.WithContext(ctx).ErrorF("VariantReview-TestGet failed: %v", err)
+		return nil, err
+	}
+
+	if test.FinishAt == nil {
+		return nil, constants.ErrorTestNotFinished
+	}
+
+	reviews, err := v.testingRepo.TestReview(ctx, variant.OrganizationId, test.ID)
+	if err != nil {
+		v.log.WithContext(ctx).ErrorF("VariantReview failed: %v", err)
+		return nil, err
+	}
+
+	for _, review := range reviews {
+		if !variant.RevealAnswers {
+			review.CorrectAnswer = ""
+		}
+		if !variant.RevealExplanations {
+			review.Explanation = ""
+			review.Feedback = ""
+		}
+	}
+
+	return reviews, nil
+}
+
+func (v *Variant) VariantFinalizeExpired(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.VariantFinalizeExpired")
+	defer span.End()
+
+	variants, err := v.repo.VariantExpired(ctx)
+	if err != nil {
+		v.log.WithContext(ctx).ErrorF("VariantFinalizeExpired-VariantExpired failed: %v", err)
+		return 0, err
+	}
+
+	var finalized int
+	for _, variant := range variants {
+		tests, err := v.testingRepo.TestUnfinished(ctx, variant.OrganizationId, variant.Id)
+		if err != nil {
+			v.log.WithContext(ctx).ErrorF("VariantFinalizeExpired-TestUnfinished failed: %v", err)
+			return finalized, err
+		}
+
+		for _, test := range tests {
+			user := &entities.User{ID: test.UserId, OrganizationId: variant.OrganizationId, Login: test.Login}
+			if _, err := v.VariantResults(ctx, variant, user); err != nil {
+				return finalized, err
+			}
+			finalized++
+		}
+	}
+
+	return finalized, nil
+}
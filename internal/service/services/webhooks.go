@@ -0,0 +1,303 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"quiz-service/init/logger"
+	"quiz-service/internal/entities"
+	"quiz-service/internal/repository"
+	"quiz-service/internal/tracing"
+	"quiz-service/pkg/constants"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookBatch       = 20
+	webhookTimeout     = 10 * time.Second
+	webhookLease       = 5 * time.Minute
+	webhookRetryBase   = 30 * time.Second
+	webhookMaxAttempts = 8
+)
+
+type Webhooks struct {
+	repo repository.WebhooksRepository
+
+	client *http.Client
+
+	log logger.Logging
+}
+
+func NewWebhooks(repo repository.WebhooksRepository, log logger.Logging) *Webhooks {
+	// the dialer checks the address every connection actually goes to, so a
+	// host that resolved to a public address when the webhook was added and to
+	// an internal one since, or a redirect into the network, is refused too
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: webhookDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Webhooks{repo: repo, client: &http.Client{Timeout: webhookTimeout, Transport: transport}, log: log}
+}
+
+func (w *Webhooks) WebhookAdd(ctx context.Context, tenantId int, webhook *entities.Webhook) (*entities.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "service.WebhookAdd")
+	defer span.End()
+
+	if err := webhookResolve(ctx, webhook.Url); err != nil {
+		return nil, err
+	}
+
+	if webhook.Secret == "" {
+		secret, err := randomCode(32)
+		if err != nil {
+			w.log.WithContext(ctx).ErrorF("WebhookAdd-randomCode failed: %v", err)
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	created, err := w.repo.WebhookAdd(ctx, tenantId, webhook)
+	if err != nil {
+		w.log.WithContext(ctx).ErrorF("WebhookAdd failed: %v", err)
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (w *Webhooks) WebhookList(ctx context.Context, tenantId int) ([]*entities.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "service.WebhookList")
+	defer span.End()
+
+	webhooks, err := w.repo.WebhookList(ctx, tenantId)
+	if err != nil {
+		w.log.WithContext(ctx).ErrorF("WebhookList failed: %v", err)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (w *Webhooks) WebhookRemove(ctx context.Context, tenantId, webhookId int) error {
+	ctx, span := tracing.Start(ctx, "service.WebhookRemove")
+	defer span.End()
+
+	num, err := w.repo.WebhookRemove(ctx, tenantId, webhookId)
+	if err != nil {
+		w.log.WithContext(ctx).ErrorF("WebhookRemove failed: %v", err)
+		return err
+	}
+	if num == 0 {
+		return constants.ErrorWebhookNotFound
+	}
+
+	return nil
+}
+
+func (w *Webhooks) WebhookDeliveries(ctx context.Context, tenantId, webhookId int) ([]*entities.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "service.WebhookDeliveries")
+	defer span.End()
+
+	deliveries, err := w.repo.WebhookDeliveries(ctx, tenantId, webhookId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, constants.ErrorWebhookNotFound
+		}
+		w.log.WithContext(ctx).ErrorF("WebhookDeliveries failed: %v", err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// WebhookDispatch moves new outbox events into deliveries and sends the deliveries
+// that are due. It returns how many deliveries were attempted.
+func (w *Webhooks) WebhookDispatch(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.WebhookDispatch")
+	defer span.End()
+
+	if _, err := w.repo.WebhookFanout(ctx, webhookBatch*5); err != nil {
+		w.log.WithContext(ctx).ErrorF("WebhookDispatch-WebhookFanout failed: %v", err)
+		return 0, err
+	}
+
+	calls, err := w.repo.WebhookDue(ctx, webhookBatch, webhookLease)
+	if err != nil {
+		w.log.WithContext(ctx).ErrorF("WebhookDispatch-WebhookDue failed: %v", err)
+		return 0, err
+	}
+
+	for _, call := range calls {
+		statusCode, callErr := w.send(ctx, call)
+
+		status := entities.DeliveryDelivered
+		nextAttemptAt := time.Now()
+		errMessage := ""
+		if callErr != nil {
+			errMessage = callErr.Error()
+
+			attempts := call.Attempts + 1
+			if attempts >= webhookMaxAttempts {
+				status = entities.DeliveryFailed
+			} else {
+				status = entities.DeliveryPending
+				nextAttemptAt = nextAttemptAt.Add(webhookBackoff(attempts))
+			}
+		}
+
+		if err := w.repo.WebhookAttempt(ctx, call.DeliveryId, status, statusCode, errMessage, nextAttemptAt); err != nil {
+			w.log.WithContext(ctx).ErrorF("WebhookDispatch-WebhookAttempt failed: %v", err)
+			return 0, err
+		}
+	}
+
+	return len(calls), nil
+}
+
+func (w *Webhooks) send(ctx context.Context, call *entities.WebhookCall) (int, error) {
+	body, err := json.Marshal(&entities.WebhookPayload{
+		Id:        call.OutboxId,
+		Event:     call.Event,
+		CreatedAt: call.CreatedAt,
+		Data:      call.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, call.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "quiz-service-webhooks")
+	req.Header.Set("X-Webhook-Event", call.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(call.DeliveryId, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", webhookSignature(call.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// webhookSignature signs "timestamp.body", so a captured request can't be replayed
+// with a fresh timestamp.
+func webhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff doubles the wait after every failed attempt: 30s, 1m, 2m, ... ~32m.
+func webhookBackoff(attempts int) time.Duration {
+	return webhookRetryBase << (attempts - 1)
+}
+
+// webhookResolve accepts an http(s) url only when every address of its host
+// is public, so members' events can't be posted into the service's network.
+func webhookResolve(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return constants.ErrorWebhookAddress
+	}
+
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		if !publicAddress(ip) {
+			return constants.ErrorWebhookAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return constants.ErrorWebhookAddress
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return constants.ErrorWebhookAddress
+		}
+	}
+
+	return nil
+}
+
+// webhookDial refuses to connect a delivery to anything but a public address.
+func webhookDial(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", constants.ErrorWebhookAddress, host)
+	}
+	return nil
+}
+
+// reservedNetworks are the special-purpose ranges the net.IP checks leave out:
+// shared carrier-grade NAT, protocol assignments, benchmarking, documentation,
+// the reserved class E, and the IPv6 prefixes that carry an IPv4 address.
+var reservedNetworks = func() []*net.IPNet {
+	cidrs := []string{
+		"0.0.0.0/8",
+		"100.64.0.0/10",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"240.0.0.0/4",
+		"64:ff9b::/96",
+		"64:ff9b:1::/48",
+		"100::/64",
+		"2001::/23",
+		"2001:db8::/32",
+		"2002::/16",
+	}
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+func publicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
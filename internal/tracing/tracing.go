@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"quiz-service/init/config"
+)
+
+const (
+	ServiceName = "quiz-service"
+
+	ExporterNone   = ""
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Init installs the global tracer provider and the W3C trace-context
+// propagator. With no exporter configured spans are still created, so trace
+// ids reach the logs and outgoing calls, but nothing is exported. The returned
+// func flushes pending spans and must be called on shutdown.
+func Init(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	}
+
+	switch cfg.Tracing.Exporter {
+	case ExporterNone:
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		clientOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Tracing.Endpoint)}
+		if cfg.Tracing.Insecure {
+			clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Tracing.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start opens a span named after the layer and method, e.g.
+// "service.VariantAdd", as a child of the span in ctx.
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(ServiceName).Start(ctx, name)
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS practice_answers;
+DROP TABLE IF EXISTS practice_testing;
+ALTER TABLE questions DROP COLUMN IF EXISTS explanation;
@@ -0,0 +1,28 @@
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS explanation VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Тренировочные прохождения. Не ограничены по количеству и не влияют на результаты в testing
+CREATE TABLE IF NOT EXISTS practice_testing (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL,
+    start_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    correct_answers INTEGER DEFAULT 0,
+    attempts INTEGER DEFAULT 0,
+    finish_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL,
+    FOREIGN KEY (user_id) REFERENCES auth(id),
+    FOREIGN KEY (variant_id) REFERENCES variants(id) ON DELETE CASCADE
+);
+--
+
+-- Ответы в тренировочном режиме, каждая попытка хранится отдельно
+CREATE TABLE IF NOT EXISTS practice_answers (
+    id SERIAL PRIMARY KEY,
+    practice_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    answer VARCHAR(50) NOT NULL,
+    correct BOOLEAN NOT NULL,
+    answered_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (practice_id) REFERENCES practice_testing(id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES questions(id) ON DELETE CASCADE
+);
+--
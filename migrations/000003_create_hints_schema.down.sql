@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS revealed_hints;
+DROP TABLE IF EXISTS hints;
+ALTER TABLE testing DROP COLUMN IF EXISTS penalty;
+ALTER TABLE variants DROP COLUMN IF EXISTS reveal_explanations;
+ALTER TABLE variants DROP COLUMN IF EXISTS reveal_answers;
+ALTER TABLE answers DROP COLUMN IF EXISTS feedback;
@@ -0,0 +1,25 @@
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS feedback VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS reveal_answers BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS reveal_explanations BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE testing ADD COLUMN IF NOT EXISTS penalty NUMERIC(8, 2) NOT NULL DEFAULT 0;
+
+-- Подсказки к вопросу, каждая открытая подсказка снижает результат на penalty
+CREATE TABLE IF NOT EXISTS hints (
+    id SERIAL PRIMARY KEY,
+    question_id INTEGER NOT NULL,
+    hint VARCHAR(255) NOT NULL,
+    penalty NUMERIC(8, 2) NOT NULL DEFAULT 0,
+    FOREIGN KEY (question_id) REFERENCES questions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS revealed_hints (
+    test_id INTEGER NOT NULL,
+    hint_id INTEGER NOT NULL,
+    revealed_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (test_id) REFERENCES testing(id) ON DELETE CASCADE,
+    FOREIGN KEY (hint_id) REFERENCES hints(id) ON DELETE CASCADE,
+    UNIQUE (test_id, hint_id)
+);
+--
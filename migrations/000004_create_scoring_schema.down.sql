@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_answer_scores;
+ALTER TABLE testing DROP COLUMN IF EXISTS max_score;
+ALTER TABLE testing DROP COLUMN IF EXISTS score;
+ALTER TABLE answers DROP COLUMN IF EXISTS correct;
+ALTER TABLE questions DROP COLUMN IF EXISTS multiple;
+ALTER TABLE questions DROP COLUMN IF EXISTS negative_points;
+ALTER TABLE questions DROP COLUMN IF EXISTS points;
@@ -0,0 +1,20 @@
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS points NUMERIC(8, 2) NOT NULL DEFAULT 1;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS negative_points NUMERIC(8, 2) NOT NULL DEFAULT 0;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS multiple BOOLEAN NOT NULL DEFAULT false;
+
+-- Дополнительные правильные варианты для вопросов с множественным выбором
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS correct BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE testing ADD COLUMN IF NOT EXISTS score NUMERIC(8, 2) NOT NULL DEFAULT 0;
+ALTER TABLE testing ADD COLUMN IF NOT EXISTS max_score NUMERIC(8, 2) NOT NULL DEFAULT 0;
+
+-- Баллы, начисленные за ответ на вопрос. На каждый вопрос можно ответить один раз
+CREATE TABLE IF NOT EXISTS user_answer_scores (
+    test_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    points NUMERIC(8, 2) NOT NULL DEFAULT 0,
+    FOREIGN KEY (test_id) REFERENCES testing(id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES questions(id) ON DELETE CASCADE,
+    UNIQUE (test_id, question_id)
+);
+--
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS certificates;
+ALTER TABLE variants DROP COLUMN IF EXISTS pass_mark;
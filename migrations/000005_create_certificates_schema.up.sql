@@ -0,0 +1,10 @@
+-- Проходной балл в процентах от max_score, 0 - сертификат не выдаётся
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS pass_mark NUMERIC(5, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS certificates (
+    id SERIAL PRIMARY KEY,
+    test_id INTEGER NOT NULL UNIQUE,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    issued_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (test_id) REFERENCES testing(id) ON DELETE CASCADE
+);
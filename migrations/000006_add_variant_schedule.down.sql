@@ -0,0 +1,2 @@
+ALTER TABLE variants DROP COLUMN IF EXISTS closes_at;
+ALTER TABLE variants DROP COLUMN IF EXISTS opens_at;
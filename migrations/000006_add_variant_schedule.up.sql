@@ -0,0 +1,3 @@
+-- Окно доступности варианта, NULL - без ограничения
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS opens_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS closes_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
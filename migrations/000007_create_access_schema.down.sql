@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS variant_assignments;
+DROP TABLE IF EXISTS access_codes;
+ALTER TABLE variants DROP COLUMN IF EXISTS restricted;
@@ -0,0 +1,28 @@
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS restricted BOOLEAN NOT NULL DEFAULT false;
+
+-- Коды доступа и одноразовые приглашения к закрытым вариантам. max_uses = 0 - без ограничения
+CREATE TABLE IF NOT EXISTS access_codes (
+    id SERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    uses INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (variant_id) REFERENCES variants(id) ON DELETE CASCADE
+);
+--
+
+-- Пользователи, допущенные к прохождению варианта
+CREATE TABLE IF NOT EXISTS variant_assignments (
+    variant_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    access_code_id INTEGER DEFAULT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (variant_id) REFERENCES variants(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES auth(id) ON DELETE CASCADE,
+    FOREIGN KEY (access_code_id) REFERENCES access_codes(id) ON DELETE SET NULL,
+    UNIQUE (variant_id, user_id)
+);
+--
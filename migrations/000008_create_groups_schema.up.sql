@@ -0,0 +1,31 @@
+-- Группы (классы) пользователей
+CREATE TABLE IF NOT EXISTS groups (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES auth(id) ON DELETE CASCADE,
+    UNIQUE (owner_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS group_members (
+    group_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES auth(id) ON DELETE CASCADE,
+    UNIQUE (group_id, user_id)
+);
+--
+
+-- Варианты, назначенные группе, со сроком сдачи
+CREATE TABLE IF NOT EXISTS group_assignments (
+    group_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (variant_id) REFERENCES variants(id) ON DELETE CASCADE,
+    UNIQUE (group_id, variant_id)
+);
+--
@@ -0,0 +1,9 @@
+-- Глобальная уникальность имён не восстанавливается: у разных организаций могут быть
+-- варианты с одинаковым именем, и откат не должен падать или удалять их
+ALTER TABLE variants DROP CONSTRAINT IF EXISTS variants_organization_id_name_key;
+
+ALTER TABLE groups DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE variants DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE auth DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,27 @@
+-- Организации (тенанты): пользователи, варианты и результаты принадлежат одной организации
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Уже существующие данные переезжают в организацию по умолчанию
+INSERT INTO organizations (name) VALUES ('default') ON CONFLICT (name) DO NOTHING;
+--
+
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id);
+UPDATE auth SET organization_id = (SELECT id FROM organizations WHERE name = 'default') WHERE organization_id IS NULL;
+ALTER TABLE auth ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE variants SET organization_id = (SELECT id FROM organizations WHERE name = 'default') WHERE organization_id IS NULL;
+ALTER TABLE variants ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE groups SET organization_id = (SELECT id FROM organizations WHERE name = 'default') WHERE organization_id IS NULL;
+ALTER TABLE groups ALTER COLUMN organization_id SET NOT NULL;
+
+-- Имя варианта уникально только внутри организации
+ALTER TABLE variants DROP CONSTRAINT IF EXISTS variants_name_key;
+ALTER TABLE variants ADD CONSTRAINT variants_organization_id_name_key UNIQUE (organization_id, name);
+--
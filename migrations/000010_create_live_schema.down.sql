@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS live_results;
+DROP TABLE IF EXISTS live_sessions;
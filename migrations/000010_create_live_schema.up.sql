@@ -0,0 +1,25 @@
+-- Живые сессии: ведущий открывает вариант по PIN и переключает вопросы для всех участников
+CREATE TABLE IF NOT EXISTS live_sessions (
+    id SERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL,
+    host_id INTEGER NOT NULL,
+    pin VARCHAR(6) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    FOREIGN KEY (variant_id) REFERENCES variants(id) ON DELETE CASCADE,
+    FOREIGN KEY (host_id) REFERENCES auth(id) ON DELETE CASCADE
+);
+--
+
+-- Итоговая таблица лидеров сессии
+CREATE TABLE IF NOT EXISTS live_results (
+    session_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    rank INTEGER NOT NULL,
+    score NUMERIC(10,2) NOT NULL DEFAULT 0,
+    correct_answers INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (session_id) REFERENCES live_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES auth(id) ON DELETE CASCADE,
+    UNIQUE (session_id, user_id)
+);
+--
@@ -0,0 +1,3 @@
+ALTER TABLE auth DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE auth DROP CONSTRAINT IF EXISTS auth_role_check;
+ALTER TABLE auth DROP COLUMN IF EXISTS role;
@@ -0,0 +1,6 @@
+-- Роль пользователя и отметка об отключении, которыми управляет административный CLI
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'user';
+ALTER TABLE auth ADD CONSTRAINT auth_role_check CHECK (role IN ('user', 'admin'));
+
+-- Отключенный пользователь не может войти, а его сессия перестает действовать
+ALTER TABLE auth ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NULL;
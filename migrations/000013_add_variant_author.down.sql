@@ -0,0 +1 @@
+ALTER TABLE variants DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,3 @@
+-- Автор варианта: только он и администраторы организации меняют вариант.
+-- У вариантов, созданных до появления колонки, автора нет, ими управляют администраторы
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS author_id INTEGER DEFAULT NULL REFERENCES auth(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Код приглашения: по нему пользователь регистрируется в организации.
+-- Без кода регистрация ведет в организацию по умолчанию, у которой кода нет
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS invite_code VARCHAR(32) DEFAULT NULL UNIQUE;
+UPDATE organizations SET invite_code = upper(md5(random()::text)) WHERE name <> 'default' AND invite_code IS NULL;
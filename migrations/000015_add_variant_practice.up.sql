@@ -0,0 +1,3 @@
+-- Тренировочный режим включается автором для каждого варианта отдельно:
+-- он сразу показывает правильность ответов, поэтому по умолчанию выключен
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS practice BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations into the binary, so it runs from
+// any working directory and the schema it expects always travels with it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,73 @@
+package certificate
+
+import (
+	"bytes"
+	_ "embed"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+//go:embed template.tmpl
+var defaultTemplate string
+
+type Data struct {
+	Login    string
+	Variant  string
+	Score    float64
+	MaxScore float64
+	Date     time.Time
+	Code     string
+}
+
+type Generator interface {
+	Generate(data *Data) ([]byte, error)
+}
+
+type PDFGenerator struct {
+	tmpl *template.Template
+}
+
+func NewPDFGenerator() *PDFGenerator {
+	return &PDFGenerator{tmpl: template.Must(template.New("certificate").Parse(defaultTemplate))}
+}
+
+// Generate renders the template and lays it out on a landscape A4 page: the
+// first line is the title, every following line is centered body text.
+func (g *PDFGenerator) Generate(data *Data) ([]byte, error) {
+	var text bytes.Buffer
+	if err := g.tmpl.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.SetTitle("Certificate "+data.Code, true)
+	pdf.AddPage()
+
+	width, height := pdf.GetPageSize()
+	pdf.SetLineWidth(1)
+	pdf.Rect(10, 10, width-20, height-20, "D")
+
+	pdf.SetY(40)
+	pdf.SetFont("go", "B", 32)
+	pdf.CellFormat(0, 20, lines[0], "", 1, "C", false, 0, "")
+	pdf.Ln(10)
+
+	pdf.SetFont("go", "", 16)
+	for _, line := range lines[1:] {
+		pdf.CellFormat(0, 12, line, "", 1, "C", false, 0, "")
+	}
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -1,21 +1,84 @@
 package constants
 
-import "errors"
+import "net/http"
+
+// Error is a domain error with a stable machine-readable code and the http
+// status it is answered with. Codes are part of the API and must not change.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(status int, code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
 
 var (
-	ErrorUserAlreadyExists = errors.New("user already exists")
-	ErrorUserNotFound      = errors.New("user not found")
-	ErrorUserNotAuthorized = errors.New("user not authorized")
+	ErrorInternal         = newError(http.StatusInternalServerError, "internal", "internal error")
+	ErrorInvalidBody      = newError(http.StatusBadRequest, "invalid_body", "invalid request body")
+	ErrorInvalidParameter = newError(http.StatusBadRequest, "invalid_parameter", "invalid path parameter")
+	ErrorTimeout          = newError(http.StatusGatewayTimeout, "timeout", "the operation timed out")
+
+	ErrorUserAlreadyExists = newError(http.StatusConflict, "user_already_exists", "user already exists")
+	ErrorUserNotFound      = newError(http.StatusNotFound, "user_not_found", "user not found")
+	ErrorUserNotAuthorized = newError(http.StatusUnauthorized, "user_not_authorized", "user not authorized")
+	ErrorUserRoleInvalid   = newError(http.StatusBadRequest, "user_role_invalid", "role must be user or admin")
+	ErrorUserNotAdmin      = newError(http.StatusForbidden, "user_not_admin", "only an admin can do this")
+
+	ErrorOrganizationAlreadyExists = newError(http.StatusConflict, "organization_already_exists", "organization already exists")
+	ErrorOrganizationNotFound      = newError(http.StatusNotFound, "organization_not_found", "organization not found")
+	ErrorInviteNotFound            = newError(http.StatusNotFound, "invite_not_found", "invite code not found")
+
+	ErrorVariantAlreadyExists = newError(http.StatusConflict, "variant_already_exists", "variant already exists")
+	ErrorVariantTooLong       = newError(http.StatusBadRequest, "variant_too_long", "variant too long: >16")
+	ErrorVariantNotFound      = newError(http.StatusNotFound, "variant_not_found", "variant not found")
+	ErrorNoVariantsYet        = newError(http.StatusNotFound, "no_variants_yet", "no variants yet")
+	ErrorVariantCompleted     = newError(http.StatusConflict, "variant_completed", "variant completed")
+	ErrorVariantNotOpen       = newError(http.StatusForbidden, "variant_not_open", "variant is not open yet")
+	ErrorVariantClosed        = newError(http.StatusForbidden, "variant_closed", "variant is closed")
+	ErrorVariantSchedule      = newError(http.StatusBadRequest, "variant_schedule", "variant must close after it opens")
+	ErrorVariantNotAuthor     = newError(http.StatusForbidden, "variant_not_author", "only the author or an admin can manage the variant")
+
+	ErrorQuestionAlreadyExists = newError(http.StatusConflict, "question_already_exists", "question already exists")
+	ErrorQuestionNotFound      = newError(http.StatusNotFound, "question_not_found", "question not found")
+	ErrorQuestionLimitExceeded = newError(http.StatusBadRequest, "question_limit_exceeded", "question limit exceeded")
+	ErrorNoHintsLeft           = newError(http.StatusNotFound, "no_hints_left", "no hints left")
+	ErrorQuestionAnswered      = newError(http.StatusConflict, "question_answered", "question already answered")
+	ErrorQuestionNotMultiple   = newError(http.StatusBadRequest, "question_not_multiple", "only multiple choice questions can have several correct answers")
+
+	ErrorTestNotFound       = newError(http.StatusNotFound, "test_not_found", "testing not found")
+	ErrorTestNotFinished    = newError(http.StatusConflict, "test_not_finished", "testing not finished")
+	ErrorTestAlreadyStarted = newError(http.StatusConflict, "test_already_started", "testing already started")
+	ErrorPracticeNotFound   = newError(http.StatusNotFound, "practice_not_found", "practice not found")
+	ErrorPracticeDisabled   = newError(http.StatusForbidden, "practice_disabled", "practice is not enabled for the variant")
+
+	ErrorCertificateNotFound = newError(http.StatusNotFound, "certificate_not_found", "certificate not found")
+
+	ErrorVariantRestricted = newError(http.StatusForbidden, "variant_restricted", "variant is restricted to invited users")
+	ErrorAccessNotFound    = newError(http.StatusNotFound, "access_not_found", "access code not found")
+	ErrorAccessExpired     = newError(http.StatusForbidden, "access_expired", "access code expired")
+	ErrorAccessExhausted   = newError(http.StatusForbidden, "access_exhausted", "access code usage limit reached")
+
+	ErrorGroupAlreadyExists = newError(http.StatusConflict, "group_already_exists", "group already exists")
+	ErrorGroupNotFound      = newError(http.StatusNotFound, "group_not_found", "group not found")
+	ErrorGroupNotOwner      = newError(http.StatusForbidden, "group_not_owner", "group belongs to another user")
 
-	ErrorVariantAlreadyExists = errors.New("variant already exists")
-	ErrorVariantTooLong       = errors.New("variant too long: >16")
-	ErrorVariantNotFound      = errors.New("variant not found")
-	ErrorNoVariantsYet        = errors.New("no variants yet")
-	ErrorVariantCompleted     = errors.New("variant completed")
+	ErrorLiveNotFound      = newError(http.StatusNotFound, "live_not_found", "live session not found")
+	ErrorLiveNotHost       = newError(http.StatusForbidden, "live_not_host", "only the host can control the live session")
+	ErrorLiveNoQuestions   = newError(http.StatusBadRequest, "live_no_questions", "variant has no questions")
+	ErrorLiveNoQuestion    = newError(http.StatusConflict, "live_no_question", "no question is open")
+	ErrorLiveTimeUp        = newError(http.StatusConflict, "live_time_up", "time is up for this question")
+	ErrorLiveWrongQuestion = newError(http.StatusBadRequest, "live_wrong_question", "answer is for another question")
 
-	ErrorQuestionAlreadyExists = errors.New("question already exists")
-	ErrorQuestionNotFound      = errors.New("question not found")
-	ErrorQuestionLimitExceeded = errors.New("question limit exceeded")
+	ErrorWebhookNotFound = newError(http.StatusNotFound, "webhook_not_found", "webhook not found")
+	ErrorWebhookAddress  = newError(http.StatusBadRequest, "webhook_address", "webhook url must resolve to public addresses only")
 
-	ErrorTestNotFound = errors.New("testing not found")
+	ErrorDraining            = newError(http.StatusServiceUnavailable, "draining", "service is shutting down")
+	ErrorDatabaseUnavailable = newError(http.StatusServiceUnavailable, "database_unavailable", "database is unavailable")
+	ErrorSchemaVersion       = newError(http.StatusServiceUnavailable, "schema_version", "database schema is not at the expected version")
 )
@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	params map[string]*Schema
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	Url string `json:"url"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement maps a security scheme name to its scopes.
+type SecurityRequirement map[string][]string
+
+// PathItem maps a lower-case http method to its operation.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+func New(title, version, serverUrl string) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Servers:    []Server{{Url: serverUrl}},
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+		params:     make(map[string]*Schema),
+	}
+}
+
+// Security registers a security scheme operations can refer to by name.
+func (d *Document) Security(name string, scheme *SecurityScheme) {
+	if d.Components.SecuritySchemes == nil {
+		d.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	d.Components.SecuritySchemes[name] = scheme
+}
+
+// Param sets the schema of a path parameter wherever it appears; parameters
+// without one are plain strings.
+func (d *Document) Param(name string, schema *Schema) {
+	d.params[name] = schema
+}
+
+// Add documents a route given in gin syntax, e.g. "/:userId/group/:groupId/get".
+// Path parameters are filled in from the route itself.
+func (d *Document) Add(method, ginPath string, operation *Operation) {
+	path, params := convertPath(ginPath)
+	for _, name := range params {
+		schema, ok := d.params[name]
+		if !ok {
+			schema = String()
+		}
+		operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = operation
+}
+
+// Has reports whether the route, in gin syntax, is documented.
+func (d *Document) Has(method, ginPath string) bool {
+	path, _ := convertPath(ginPath)
+	item, ok := d.Paths[path]
+	if !ok {
+		return false
+	}
+	_, ok = item[strings.ToLower(method)]
+	return ok
+}
+
+func convertPath(ginPath string) (string, []string) {
+	var params []string
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// JSON is a request body or response content of the given schema.
+func JSON(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
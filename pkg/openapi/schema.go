@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func Integer() *Schema {
+	return &Schema{Type: "integer"}
+}
+
+// Object is an inline object schema with the given properties.
+func Object(properties map[string]*Schema) *Schema {
+	return &Schema{Type: "object", Properties: properties}
+}
+
+// Schema describes v, registering every named struct it reaches under
+// components/schemas. Field names come from json tags, constraints from the gin
+// binding tags, so the document follows the entities as they change.
+func (d *Document) Schema(v any) *Schema {
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := d.schemaOf(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structOf(t)
+		}
+		if _, ok := d.Components.Schemas[t.Name()]; !ok {
+			// Registered before the fields are walked, so self references terminate.
+			d.Components.Schemas[t.Name()] = &Schema{}
+			*d.Components.Schemas[t.Name()] = *d.structOf(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func (d *Document) structOf(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := d.structOf(field.Type)
+			for property, s := range embedded.Properties {
+				schema.Properties[property] = s
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := d.schemaOf(field.Type)
+		if applyBinding(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// applyBinding copies the validator rules that have an OpenAPI counterpart onto
+// the schema and reports whether the field is required. Rules after "dive"
+// describe the items of a slice.
+func applyBinding(schema *Schema, binding string) bool {
+	if binding == "" {
+		return false
+	}
+
+	var required bool
+	target := schema
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+		case "url":
+			target.Format = "uri"
+		case "oneof":
+			target.Enum = strings.Fields(value)
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			limit(target, name, n)
+		}
+	}
+
+	return required
+}
+
+func limit(schema *Schema, rule string, n float64) {
+	count := int(n)
+	switch schema.Type {
+	case "string":
+		if rule != "max" {
+			schema.MinLength = &count
+		}
+		if rule != "min" {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if rule != "max" {
+			schema.MinItems = &count
+		}
+		if rule != "min" {
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		if rule != "max" {
+			schema.Minimum = &n
+		}
+		if rule != "min" {
+			schema.Maximum = &n
+		}
+	}
+}
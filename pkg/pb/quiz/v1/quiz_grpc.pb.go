@@ -0,0 +1,700 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: quiz/v1/quiz.proto
+
+package quizv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuizService_Register_FullMethodName              = "/quiz.v1.QuizService/Register"
+	QuizService_Login_FullMethodName                 = "/quiz.v1.QuizService/Login"
+	QuizService_Quit_FullMethodName                  = "/quiz.v1.QuizService/Quit"
+	QuizService_AddVariant_FullMethodName            = "/quiz.v1.QuizService/AddVariant"
+	QuizService_ListVariants_FullMethodName          = "/quiz.v1.QuizService/ListVariants"
+	QuizService_GetVariant_FullMethodName            = "/quiz.v1.QuizService/GetVariant"
+	QuizService_RemoveVariant_FullMethodName         = "/quiz.v1.QuizService/RemoveVariant"
+	QuizService_UpdateVariantSettings_FullMethodName = "/quiz.v1.QuizService/UpdateVariantSettings"
+	QuizService_AddQuestion_FullMethodName           = "/quiz.v1.QuizService/AddQuestion"
+	QuizService_RemoveQuestion_FullMethodName        = "/quiz.v1.QuizService/RemoveQuestion"
+	QuizService_GetQuestion_FullMethodName           = "/quiz.v1.QuizService/GetQuestion"
+	QuizService_StartAttempt_FullMethodName          = "/quiz.v1.QuizService/StartAttempt"
+	QuizService_AnswerQuestion_FullMethodName        = "/quiz.v1.QuizService/AnswerQuestion"
+	QuizService_TakeHint_FullMethodName              = "/quiz.v1.QuizService/TakeHint"
+	QuizService_FinishAttempt_FullMethodName         = "/quiz.v1.QuizService/FinishAttempt"
+	QuizService_ReviewAttempt_FullMethodName         = "/quiz.v1.QuizService/ReviewAttempt"
+)
+
+// QuizServiceClient is the client API for QuizService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QuizService exposes the operations of the HTTP API to other backend services.
+// Every call except Register and Login carries the user's uuid in the
+// "x-user-id" metadata key, like the :userId segment of the HTTP routes.
+type QuizServiceClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*User, error)
+	Quit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddVariant(ctx context.Context, in *AddVariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListVariants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListVariantsResponse, error)
+	GetVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Variant, error)
+	RemoveVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateVariantSettings(ctx context.Context, in *UpdateVariantSettingsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddQuestion(ctx context.Context, in *AddQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveQuestion(ctx context.Context, in *RemoveQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetQuestion(ctx context.Context, in *QuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	StartAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Variant, error)
+	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TakeHint(ctx context.Context, in *QuestionRequest, opts ...grpc.CallOption) (*Hint, error)
+	FinishAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Attempt, error)
+	ReviewAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*ReviewAttemptResponse, error)
+}
+
+type quizServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuizServiceClient(cc grpc.ClientConnInterface) QuizServiceClient {
+	return &quizServiceClient{cc}
+}
+
+func (c *quizServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, QuizService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, QuizService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) Quit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_Quit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) AddVariant(ctx context.Context, in *AddVariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_AddVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ListVariants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVariantsResponse)
+	err := c.cc.Invoke(ctx, QuizService_ListVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Variant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, QuizService_GetVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) RemoveVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_RemoveVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) UpdateVariantSettings(ctx context.Context, in *UpdateVariantSettingsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_UpdateVariantSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) AddQuestion(ctx context.Context, in *AddQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_AddQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) RemoveQuestion(ctx context.Context, in *RemoveQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_RemoveQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetQuestion(ctx context.Context, in *QuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuizService_GetQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) StartAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Variant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, QuizService_StartAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QuizService_AnswerQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) TakeHint(ctx context.Context, in *QuestionRequest, opts ...grpc.CallOption) (*Hint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hint)
+	err := c.cc.Invoke(ctx, QuizService_TakeHint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) FinishAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*Attempt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attempt)
+	err := c.cc.Invoke(ctx, QuizService_FinishAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ReviewAttempt(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*ReviewAttemptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewAttemptResponse)
+	err := c.cc.Invoke(ctx, QuizService_ReviewAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuizServiceServer is the server API for QuizService service.
+// All implementations must embed UnimplementedQuizServiceServer
+// for forward compatibility.
+//
+// QuizService exposes the operations of the HTTP API to other backend services.
+// Every call except Register and Login carries the user's uuid in the
+// "x-user-id" metadata key, like the :userId segment of the HTTP routes.
+type QuizServiceServer interface {
+	Register(context.Context, *RegisterRequest) (*User, error)
+	Login(context.Context, *LoginRequest) (*User, error)
+	Quit(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	AddVariant(context.Context, *AddVariantRequest) (*emptypb.Empty, error)
+	ListVariants(context.Context, *emptypb.Empty) (*ListVariantsResponse, error)
+	GetVariant(context.Context, *VariantRequest) (*Variant, error)
+	RemoveVariant(context.Context, *VariantRequest) (*emptypb.Empty, error)
+	UpdateVariantSettings(context.Context, *UpdateVariantSettingsRequest) (*emptypb.Empty, error)
+	AddQuestion(context.Context, *AddQuestionRequest) (*emptypb.Empty, error)
+	RemoveQuestion(context.Context, *RemoveQuestionRequest) (*emptypb.Empty, error)
+	GetQuestion(context.Context, *QuestionRequest) (*Question, error)
+	StartAttempt(context.Context, *VariantRequest) (*Variant, error)
+	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*emptypb.Empty, error)
+	TakeHint(context.Context, *QuestionRequest) (*Hint, error)
+	FinishAttempt(context.Context, *VariantRequest) (*Attempt, error)
+	ReviewAttempt(context.Context, *VariantRequest) (*ReviewAttemptResponse, error)
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+// UnimplementedQuizServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuizServiceServer struct{}
+
+func (UnimplementedQuizServiceServer) Register(context.Context, *RegisterRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedQuizServiceServer) Login(context.Context, *LoginRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedQuizServiceServer) Quit(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quit not implemented")
+}
+func (UnimplementedQuizServiceServer) AddVariant(context.Context, *AddVariantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVariant not implemented")
+}
+func (UnimplementedQuizServiceServer) ListVariants(context.Context, *emptypb.Empty) (*ListVariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVariants not implemented")
+}
+func (UnimplementedQuizServiceServer) GetVariant(context.Context, *VariantRequest) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariant not implemented")
+}
+func (UnimplementedQuizServiceServer) RemoveVariant(context.Context, *VariantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVariant not implemented")
+}
+func (UnimplementedQuizServiceServer) UpdateVariantSettings(context.Context, *UpdateVariantSettingsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVariantSettings not implemented")
+}
+func (UnimplementedQuizServiceServer) AddQuestion(context.Context, *AddQuestionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) RemoveQuestion(context.Context, *RemoveQuestionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) GetQuestion(context.Context, *QuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) StartAttempt(context.Context, *VariantRequest) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartAttempt not implemented")
+}
+func (UnimplementedQuizServiceServer) AnswerQuestion(context.Context, *AnswerQuestionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) TakeHint(context.Context, *QuestionRequest) (*Hint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeHint not implemented")
+}
+func (UnimplementedQuizServiceServer) FinishAttempt(context.Context, *VariantRequest) (*Attempt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishAttempt not implemented")
+}
+func (UnimplementedQuizServiceServer) ReviewAttempt(context.Context, *VariantRequest) (*ReviewAttemptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewAttempt not implemented")
+}
+func (UnimplementedQuizServiceServer) mustEmbedUnimplementedQuizServiceServer() {}
+func (UnimplementedQuizServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeQuizServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuizServiceServer will
+// result in compilation errors.
+type UnsafeQuizServiceServer interface {
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+func RegisterQuizServiceServer(s grpc.ServiceRegistrar, srv QuizServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQuizServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuizService_ServiceDesc, srv)
+}
+
+func _QuizService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_Quit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).Quit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_Quit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).Quit(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_AddVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).AddVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_AddVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).AddVariant(ctx, req.(*AddVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ListVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ListVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ListVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ListVariants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetVariant(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_RemoveVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).RemoveVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_RemoveVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).RemoveVariant(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_UpdateVariantSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVariantSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).UpdateVariantSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_UpdateVariantSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).UpdateVariantSettings(ctx, req.(*UpdateVariantSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_AddQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).AddQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_AddQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).AddQuestion(ctx, req.(*AddQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_RemoveQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).RemoveQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_RemoveQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).RemoveQuestion(ctx, req.(*RemoveQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetQuestion(ctx, req.(*QuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_StartAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).StartAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_StartAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).StartAttempt(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_AnswerQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).AnswerQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_AnswerQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).AnswerQuestion(ctx, req.(*AnswerQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_TakeHint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).TakeHint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_TakeHint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).TakeHint(ctx, req.(*QuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_FinishAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).FinishAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_FinishAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).FinishAttempt(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ReviewAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ReviewAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ReviewAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ReviewAttempt(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuizService_ServiceDesc is the grpc.ServiceDesc for QuizService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuizService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quiz.v1.QuizService",
+	HandlerType: (*QuizServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _QuizService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _QuizService_Login_Handler,
+		},
+		{
+			MethodName: "Quit",
+			Handler:    _QuizService_Quit_Handler,
+		},
+		{
+			MethodName: "AddVariant",
+			Handler:    _QuizService_AddVariant_Handler,
+		},
+		{
+			MethodName: "ListVariants",
+			Handler:    _QuizService_ListVariants_Handler,
+		},
+		{
+			MethodName: "GetVariant",
+			Handler:    _QuizService_GetVariant_Handler,
+		},
+		{
+			MethodName: "RemoveVariant",
+			Handler:    _QuizService_RemoveVariant_Handler,
+		},
+		{
+			MethodName: "UpdateVariantSettings",
+			Handler:    _QuizService_UpdateVariantSettings_Handler,
+		},
+		{
+			MethodName: "AddQuestion",
+			Handler:    _QuizService_AddQuestion_Handler,
+		},
+		{
+			MethodName: "RemoveQuestion",
+			Handler:    _QuizService_RemoveQuestion_Handler,
+		},
+		{
+			MethodName: "GetQuestion",
+			Handler:    _QuizService_GetQuestion_Handler,
+		},
+		{
+			MethodName: "StartAttempt",
+			Handler:    _QuizService_StartAttempt_Handler,
+		},
+		{
+			MethodName: "AnswerQuestion",
+			Handler:    _QuizService_AnswerQuestion_Handler,
+		},
+		{
+			MethodName: "TakeHint",
+			Handler:    _QuizService_TakeHint_Handler,
+		},
+		{
+			MethodName: "FinishAttempt",
+			Handler:    _QuizService_FinishAttempt_Handler,
+		},
+		{
+			MethodName: "ReviewAttempt",
+			Handler:    _QuizService_ReviewAttempt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "quiz/v1/quiz.proto",
+}
@@ -0,0 +1,29 @@
+package version
+
+import "runtime/debug"
+
+// Commit and BuildTime are set at link time:
+//
+//	go build -ldflags "-X quiz-service/pkg/version.Commit=$(git rev-parse HEAD) -X quiz-service/pkg/version.BuildTime=$(date -u +%FT%TZ)"
+//
+// Without them they fall back to the VCS stamp go build records when run
+// inside a git checkout.
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && Commit == "unknown":
+			Commit = setting.Value
+		case setting.Key == "vcs.time" && BuildTime == "unknown":
+			BuildTime = setting.Value
+		}
+	}
+}